@@ -14,6 +14,7 @@ import (
 	"github.com/konstantinfoerster/card-importer-go/internal/logger"
 	"github.com/konstantinfoerster/card-importer-go/internal/mtgjson"
 	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+	"github.com/konstantinfoerster/card-importer-go/internal/scryfall"
 	"github.com/konstantinfoerster/card-importer-go/internal/storage"
 	"github.com/konstantinfoerster/card-importer-go/internal/timer"
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
@@ -34,11 +35,12 @@ func (a *arrayFlag) Set(value string) error {
 
 const usage = `Usage: card-dataset-cli [options...]
   --config path to the configuration file
-  --file path to local dataset json file, has precedence over the url flag or configuration file
+  --file path to local dataset json file (MTGJSON AllPrintings or Scryfall bulk data file depending on the dataset source),
+    has precedence over the url flag or configuration file
   --help prints help information
 `
 
-func setup() (*url.URL, config.Config) {
+func setup() (string, config.Config) {
 	logger.SetupConsoleLogger()
 
 	var configPaths arrayFlag
@@ -64,30 +66,29 @@ func setup() (*url.URL, config.Config) {
 	log.Info().Msgf("ARCH\t\t %s", runtime.GOARCH)
 	log.Info().Msgf("CPUs\t\t %d", runtime.NumCPU())
 
+	return file, cfg
+}
+
+func datasetSource(file string, downloadURL func() (string, error)) (*url.URL, error) {
 	if file == "" {
-		downloadURL := cfg.Mtgjson.DatasetURL
-		log.Info().Msgf("Using dataset from url %s", downloadURL)
-		u, pErr := url.Parse(downloadURL)
-		if pErr != nil {
-			panic(pErr)
+		u, err := downloadURL()
+		if err != nil {
+			return nil, err
 		}
+		log.Info().Msgf("Using dataset from url %s", u)
 
-		return u, cfg
+		return url.Parse(u)
 	}
 
 	log.Info().Msgf("Using dataset from file %s", file)
-	u, pErr := url.Parse(file)
-	if pErr != nil {
-		panic(pErr)
-	}
 
-	return u, cfg
+	return url.Parse(file)
 }
 
 func main() {
 	defer timer.TimeTrack(time.Now(), "import")
 
-	datasetSource, cfg := setup()
+	file, cfg := setup()
 
 	conn, err := postgres.Connect(context.Background(), cfg.Database)
 	if err != nil {
@@ -104,7 +105,38 @@ func main() {
 
 	csService := cards.NewSetService(cards.NewSetDao(conn))
 	cService := cards.NewCardService(cards.NewCardDao(conn))
-	imp := mtgjson.NewImporter(csService, cService)
+
+	var imp cards.Dataset
+	var source *url.URL
+	switch cfg.Dataset.SourceOrDefault() {
+	case config.SourceMtgjson:
+		imp = mtgjson.NewImporter(csService, cService)
+		source, err = datasetSource(file, func() (string, error) {
+			return cfg.Mtgjson.DatasetURL, nil
+		})
+	case config.SourceScryfall:
+		sc := &http.Client{
+			Timeout: cfg.Scryfall.Client.Timeout,
+		}
+		scryClient := scryfall.NewClient(cfg.Scryfall, web.NewClient(cfg.Scryfall.Client, sc), scryfall.DefaultLanguages)
+		imp = scryfall.NewDataset(scryClient, csService, cService)
+		source, err = datasetSource(file, func() (string, error) {
+			bd, bErr := scryClient.FindBulkData(context.Background(), cfg.Scryfall.BulkTypeOrDefault())
+			if bErr != nil {
+				return "", bErr
+			}
+
+			return bd.DownloadURI, nil
+		})
+	default:
+		err = fmt.Errorf("unsupported dataset source %s", cfg.Dataset.Source)
+	}
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to determine the dataset source")
+
+		return
+	}
+	log.Info().Msgf("Using dataset source %s", cfg.Dataset.SourceOrDefault())
 
 	store, err := storage.NewLocalStorage(cfg.Storage)
 	if err != nil {
@@ -118,7 +150,7 @@ func main() {
 	}
 	client := web.NewClient(cfg.Mtgjson.Client, c)
 	loader := mtgjson.NewLoader(imp, cfg.Mtgjson, client, store)
-	report, iErr := loader.Load(datasetSource)
+	report, iErr := loader.Load(source)
 	if iErr != nil {
 		log.Panic().Err(iErr).Msg("dataset import failed")
 
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/logger"
+	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+	"github.com/konstantinfoerster/card-importer-go/internal/timer"
+	"github.com/rs/zerolog/log"
+)
+
+type arrayFlag []string
+
+func (a *arrayFlag) String() string {
+	return fmt.Sprintf("%v", *a)
+}
+
+func (a *arrayFlag) Set(value string) error {
+	*a = append(*a, value)
+
+	return nil
+}
+
+const usage = `Usage: card-identify-cli [options...]
+  --config path to the configuration file
+  --file path to the image file (jpeg, png or gif) e.g. a photo of a card
+  --limit amount of returned matches (default: 5)
+  --variant image variant to compare with (default: normal)
+  --help prints help information
+`
+
+type flags struct {
+	file    string
+	limit   int
+	variant string
+}
+
+func setup() (flags, config.Config) {
+	logger.SetupConsoleLogger()
+
+	var configPaths arrayFlag
+	var f flags
+
+	flag.Var(&configPaths, "config", "path to the configuration files e.g. --config /config.yaml --config /secret.yaml")
+	flag.StringVar(&f.file, "file", "", "path to the image file")
+	flag.IntVar(&f.limit, "limit", 5, "amount of returned matches")
+	flag.StringVar(&f.variant, "variant", cards.VariantNormal, "image variant to compare with")
+	flag.Usage = func() { fmt.Print(usage) }
+	flag.Parse()
+
+	cfg, err := config.ReadConfigs(configPaths...)
+	if err != nil {
+		panic(err)
+	}
+
+	err = logger.SetLogLevel(cfg.Logging.LevelOrDefault())
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info().Msgf("OS\t\t %s", runtime.GOOS)
+	log.Info().Msgf("ARCH\t\t %s", runtime.GOARCH)
+	log.Info().Msgf("CPUs\t\t %d", runtime.NumCPU())
+
+	if f.file == "" {
+		panic("flag --file is required")
+	}
+
+	return f, cfg
+}
+
+func identify(ctx context.Context, identifier cards.Identifier, f flags) ([]cards.ImageMatch, error) {
+	fp := filepath.Clean(f.file)
+	// #nosec G703 should be fine here
+	file, err := os.Open(fp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s %w", fp, err)
+	}
+	defer aio.Close(file)
+
+	// unknown extensions are detected by the image decoder
+	mimeType := mime.TypeByExtension(filepath.Ext(fp))
+
+	return identifier.Identify(ctx, file, mimeType, f.variant, f.limit)
+}
+
+func main() {
+	defer timer.TimeTrack(time.Now(), "identify")
+
+	f, cfg := setup()
+
+	conn, err := postgres.Connect(context.Background(), cfg.Database)
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to connect to the database")
+
+		return
+	}
+	defer func(toCloseFn func() error) {
+		cErr := toCloseFn()
+		if cErr != nil {
+			log.Panic().Err(cErr).Msg("Failed to close database connection")
+		}
+	}(conn.Close)
+
+	matches, err := identify(context.Background(), cards.NewIdentifier(cards.NewCardDao(conn)), f)
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to identify the image")
+
+		return
+	}
+
+	if len(matches) == 0 {
+		log.Info().Msg("No matching card image found")
+
+		return
+	}
+	for i, m := range matches {
+		log.Info().Msgf("%d. %s %s %s (card %d, face %d, language %s, image %s) distance %d", i+1, m.SetCode,
+			m.Number, m.Name, m.CardID.Get(), m.FaceID.Get(), m.Lang, m.ImagePath, m.Distance)
+	}
+}
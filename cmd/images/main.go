@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/konstantinfoerster/card-importer-go/internal/archive"
 	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 	"github.com/konstantinfoerster/card-importer-go/internal/config"
 	"github.com/konstantinfoerster/card-importer-go/internal/logger"
@@ -33,24 +38,135 @@ func (a *arrayFlag) Set(value string) error {
 	return nil
 }
 
-const usage = `Usage: card-images-cli [options...]
+const usage = `Usage: card-images-cli [mode] [options...]
+Modes:
+  import downloads missing card images (default)
+  rehash recomputes the hashes of stored card images
+  refresh replaces stored card images that have changed since they were downloaded
+  symbols downloads the set icons and the mana and cost symbol images from scryfall
+  qa reports card images that are near-identical to an image they should differ from
+  verify compares the card image entries with the stored files
+
+Options import:
+  --config path to the configuration file
+  --after-id only import images of cards with a greater id, used to resume an import (default: 0)
+  --size amount of cards loaded at once (default: 20)
+  --set only import images of the set with the given code, flag can be used multiple times
+  --lang only import images of the given language e.g. deu, flag can be used multiple times
+  --number only import images of cards with the given number, flag can be used multiple times
+  --rarity only import images of cards with the given rarity e.g. mythic, flag can be used multiple times
+  --released-after only import images of sets released after the given date e.g. 2024-01-31
+  --upgrade-fallbacks replace images of the fallback language once the image of the actual language exists
+  --help prints help information
+
+Options rehash:
+  --config path to the configuration file
+  --set only rehash images of the set with the given code
+  --lang only rehash images of the given language e.g. deu
+  --help prints help information
+
+Options refresh:
+  --config path to the configuration file
+  --set only refresh images of the set with the given code
+  --lang only refresh images of the given language e.g. deu
+  --help prints help information
+
+Options symbols:
+  --config path to the configuration file
+  --help prints help information
+
+Options qa:
+  --config path to the configuration file
+  --fix delete the reported images, they are downloaded again on the next import
+  --help prints help information
+
+Options verify:
   --config path to the configuration file
-  --page start page number (default: 1)
-  --size amount of entries per page (default: 20)
+  --fix delete orphan files and broken entries, broken entries are downloaded again on the next import
   --help prints help information
 `
 
-func setup() (cards.PageConfig, config.Config) {
+const (
+	modeImport  = "import"
+	modeRehash  = "rehash"
+	modeRefresh = "refresh"
+	modeSymbols = "symbols"
+	modeQA      = "qa"
+	modeVerify  = "verify"
+)
+
+type flags struct {
+	mode          string
+	pageConfig    cards.PageConfig
+	rehashFilter  cards.RehashFilter
+	refreshFilter cards.RefreshFilter
+	fix           bool
+	upgrade       bool
+	imageFilter   cards.ImageFilter
+}
+
+func setup() (flags, config.Config) {
 	logger.SetupConsoleLogger()
 
 	var configPaths arrayFlag
-	var pageConfig cards.PageConfig
+	var setCodes, langs, numbers, rarities arrayFlag
+	var rehashSetCodes, rehashLangs arrayFlag
+	var refreshSetCodes, refreshLangs arrayFlag
+	var releasedAfter string
+	f := flags{mode: modeImport}
+
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		f.mode = args[0]
+		args = args[1:]
+	}
 
-	flag.Var(&configPaths, "config", "path to the configuration files e.g. --config /config.yaml --config /secret.yaml")
-	flag.IntVar(&pageConfig.Page, "page", 1, "start page number")
-	flag.IntVar(&pageConfig.Size, "size", 20, "amount of entries per page")
-	flag.Usage = func() { fmt.Print(usage) }
-	flag.Parse()
+	fs := flag.NewFlagSet(f.mode, flag.ExitOnError)
+	fs.Var(&configPaths, "config", "path to the configuration files e.g. --config /config.yaml --config /secret.yaml")
+	switch f.mode {
+	case modeImport:
+		fs.Int64Var(&f.pageConfig.AfterID, "after-id", 0, "only import images of cards with a greater id")
+		fs.IntVar(&f.pageConfig.Size, "size", 20, "amount of cards loaded at once")
+		fs.BoolVar(&f.upgrade, "upgrade-fallbacks", false, "only replace images of the fallback language")
+		fs.Var(&setCodes, "set", "only import images of the set with the given code")
+		fs.Var(&langs, "lang", "only import images of the given language")
+		fs.Var(&numbers, "number", "only import images of cards with the given number")
+		fs.Var(&rarities, "rarity", "only import images of cards with the given rarity")
+		fs.StringVar(&releasedAfter, "released-after", "", "only import images of sets released after the date")
+	case modeRehash:
+		fs.Var(&rehashSetCodes, "set", "only rehash images of the set with the given code")
+		fs.Var(&rehashLangs, "lang", "only rehash images of the given language")
+	case modeRefresh:
+		fs.Var(&refreshSetCodes, "set", "only refresh images of the set with the given code")
+		fs.Var(&refreshLangs, "lang", "only refresh images of the given language")
+	case modeSymbols:
+	case modeQA:
+		fs.BoolVar(&f.fix, "fix", false, "delete the reported images")
+	case modeVerify:
+		fs.BoolVar(&f.fix, "fix", false, "delete orphan files and broken entries")
+	default:
+		fmt.Print(usage)
+		panic(fmt.Sprintf("unknown mode %s", f.mode))
+	}
+	fs.Usage = func() { fmt.Print(usage) }
+	if err := fs.Parse(args); err != nil {
+		panic(err)
+	}
+	f.rehashFilter.SetCodes = normalize(rehashSetCodes, strings.ToUpper)
+	f.rehashFilter.Langs = normalize(rehashLangs, strings.ToLower)
+	f.refreshFilter.SetCodes = normalize(refreshSetCodes, strings.ToUpper)
+	f.refreshFilter.Langs = normalize(refreshLangs, strings.ToLower)
+	f.imageFilter.SetCodes = normalize(setCodes, strings.ToUpper)
+	f.imageFilter.Langs = normalize(langs, strings.ToLower)
+	f.imageFilter.Numbers = normalize(numbers, strings.TrimSpace)
+	f.imageFilter.Rarities = normalize(rarities, strings.ToUpper)
+	if strings.TrimSpace(releasedAfter) != "" {
+		released, err := time.Parse("2006-01-02", strings.TrimSpace(releasedAfter))
+		if err != nil {
+			panic(fmt.Sprintf("invalid released after date %s, expected format is YYYY-MM-DD", releasedAfter))
+		}
+		f.imageFilter.ReleasedAfter = released
+	}
 
 	cfg, err := config.ReadConfigs(configPaths...)
 	if err != nil {
@@ -65,14 +181,145 @@ func setup() (cards.PageConfig, config.Config) {
 	log.Info().Msgf("OS\t\t %s", runtime.GOOS)
 	log.Info().Msgf("ARCH\t\t %s", runtime.GOARCH)
 	log.Info().Msgf("CPUs\t\t %d", runtime.NumCPU())
+	log.Info().Msgf("Mode\t\t %s", f.mode)
 
-	return pageConfig, cfg
+	return f, cfg
+}
+
+// normalize Returns the trimmed and converted values without empty values.
+func normalize(values []string, convert func(string) string) []string {
+	var result []string
+	for _, v := range values {
+		v = convert(strings.TrimSpace(v))
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// newDownloader Creates the downloader of the configured image sources, the sources are requested in the configured
+// order. The returned function closes the sources.
+func newDownloader(ctx context.Context, cfg config.Config) (cards.ImageDownloader, func() error, error) {
+	var downloaders []cards.ImageDownloader
+	var closers []io.Closer
+	closeAll := func() error {
+		var err error
+		for _, c := range closers {
+			err = errors.Join(err, c.Close())
+		}
+
+		return err
+	}
+
+	for _, source := range cfg.Images.SourcesOrDefault() {
+		switch source {
+		case config.SourceScryfall:
+			sclient, err := newScryfallClient(ctx, cfg)
+			if err != nil {
+				return nil, nil, errors.Join(err, closeAll())
+			}
+			downloaders = append(downloaders, sclient)
+		case config.SourceArchive:
+			src, err := archive.Open(cfg.Images.Archive)
+			if err != nil {
+				return nil, nil, errors.Join(err, closeAll())
+			}
+			log.Info().Msgf("Loaded image archive %s with %d images", cfg.Images.Archive.Path, src.Len())
+			downloaders = append(downloaders, src)
+			closers = append(closers, src)
+		default:
+			return nil, nil, errors.Join(fmt.Errorf("unknown image source %s", source), closeAll())
+		}
+	}
+
+	if len(downloaders) == 1 {
+		return downloaders[0], closeAll, nil
+	}
+
+	return cards.NewChainDownloader(downloaders...), closeAll, nil
+}
+
+// newScryfallClient Creates the scryfall client and loads the image index if enabled. The index is downloaded without
+// total timeout, the download is aborted if the context is cancelled.
+func newScryfallClient(ctx context.Context, cfg config.Config) (*scryfall.Client, error) {
+	client := &http.Client{
+		Timeout: cfg.Scryfall.Client.Timeout,
+	}
+	wclient := web.NewClient(cfg.Scryfall.Client, client)
+	sclient := scryfall.NewClient(cfg.Scryfall, wclient, scryfall.DefaultLanguages)
+	if cfg.Scryfall.ImageIndex.Enabled {
+		download := web.NewClient(cfg.Scryfall.Client, web.NewDownloadHTTPClient(cfg.Scryfall.Client.Timeout))
+		idx, err := scryfall.LoadBulkIndex(ctx, sclient, download, cfg.Scryfall.ImageIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load image index %w", err)
+		}
+		log.Info().Msgf("Loaded image index with %d cards", idx.Len())
+		sclient.WithIndex(idx)
+	}
+
+	return sclient, nil
+}
+
+func logAudit(audit cards.ImageAudit) {
+	for _, p := range audit.MissingFiles {
+		log.Warn().Msgf("File %s is missing", p)
+	}
+	for _, p := range audit.EmptyFiles {
+		log.Warn().Msgf("File %s is empty", p)
+	}
+	for _, p := range audit.BrokenFiles {
+		log.Warn().Msgf("File %s can't be decoded", p)
+	}
+	for _, p := range audit.MimeMismatches {
+		log.Warn().Msgf("File %s doesn't match the mime type of the entry", p)
+	}
+	for _, p := range audit.OrphanFiles {
+		log.Warn().Msgf("File %s has no entry", p)
+	}
+
+	log.Info().Msgf("Checked: %d, missing: %d, empty: %d, broken: %d, mime mismatches: %d, orphans: %d, "+
+		"deleted orphans: %d, requeued: %d", audit.Checked, len(audit.MissingFiles), len(audit.EmptyFiles),
+		len(audit.BrokenFiles), len(audit.MimeMismatches), len(audit.OrphanFiles), audit.DeletedOrphans,
+		audit.Requeued)
+}
+
+func logQAReport(report cards.ImageQAReport) {
+	for _, f := range report.Duplicates {
+		log.Warn().Msgf("File %s of %s is near-identical to file %s of %s with distance %d", f.Image.Image.ImagePath,
+			f.Image.Filter.Name, f.Other.Image.ImagePath, f.Other.Filter.Name, f.Distance)
+	}
+	for _, f := range report.SameFaces {
+		log.Warn().Msgf("File %s of face %s is near-identical to file %s of face %s with distance %d",
+			f.Image.Image.ImagePath, f.Image.Filter.Name, f.Other.Image.ImagePath, f.Other.Filter.Name, f.Distance)
+	}
+	for _, f := range report.Placeholders {
+		log.Warn().Msgf("File %s of %s is a placeholder with distance %d", f.Image.Image.ImagePath,
+			f.Image.Filter.Name, f.Distance)
+	}
+
+	log.Info().Msgf("Checked: %d, duplicates: %d, same faces: %d, placeholders: %d, requeued: %d", report.Checked,
+		len(report.Duplicates), len(report.SameFaces), len(report.Placeholders), report.Requeued)
+}
+
+func logMisses(cardDao *cards.PostgresCardDao) error {
+	misses, err := cardDao.CountImageMisses(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, m := range misses {
+		log.Info().Msgf("Missing images of set %s with reason %s: %d", m.SetCode, m.Reason, m.Count)
+	}
+
+	return nil
 }
 
 func main() {
-	defer timer.TimeTrack(time.Now(), "images import")
+	defer timer.TimeTrack(time.Now(), "images")
 
-	pageConfig, cfg := setup()
+	f, cfg := setup()
 
 	store, err := storage.NewLocalStorage(cfg.Storage)
 	if err != nil {
@@ -100,39 +347,140 @@ func main() {
 
 	cardDao := cards.NewCardDao(conn)
 
-	client := &http.Client{
-		Timeout: cfg.Scryfall.Client.Timeout,
-	}
-	wclient := web.NewClient(cfg.Scryfall.Client, client)
-	sclient := scryfall.NewClient(cfg.Scryfall, wclient, scryfall.DefaultLanguages)
-	importer := cards.NewImageImporter(cardDao, store, sclient)
-
-	ctx := context.Background()
-	done := make(chan bool, 1)
-	nCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	nCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	go func() {
-		<-nCtx.Done()
-
-		log.Info().Msg("image import exit ...")
+	var run func(ctx context.Context) error
+	switch f.mode {
+	case modeRehash:
+		rehasher := cards.NewRehasher(cardDao, store, cfg.Images)
+		run = func(ctx context.Context) error {
+			report, rErr := rehasher.Rehash(ctx, f.rehashFilter)
+			if rErr != nil {
+				return rErr
+			}
+			log.Info().Msgf("Report %#v", report)
+
+			return nil
+		}
+	case modeSymbols:
+		// the image index is not loaded, symbols are not part of it
+		wclient := web.NewClient(cfg.Scryfall.Client, &http.Client{Timeout: cfg.Scryfall.Client.Timeout})
+		sclient := scryfall.NewClient(cfg.Scryfall, wclient, scryfall.DefaultLanguages)
+		importer := cards.NewSymbolImporter(cards.NewSetDao(conn), cards.NewSymbolDao(conn), store, sclient)
+		run = func(ctx context.Context) error {
+			report, iErr := importer.Import(ctx)
+			if iErr != nil {
+				return iErr
+			}
+			log.Info().Msgf("Report %#v", report)
+
+			return nil
+		}
+	case modeQA:
+		qa := cards.NewImageQA(cardDao, store, cfg.Images)
+		run = func(ctx context.Context) error {
+			report, qErr := qa.Inspect(ctx, f.fix)
+			if qErr != nil {
+				return qErr
+			}
+			logQAReport(report)
+
+			return nil
+		}
+	case modeVerify:
+		verifier := cards.NewImageVerifier(cardDao, store, cfg.Images)
+		run = func(ctx context.Context) error {
+			audit, vErr := verifier.Verify(ctx, f.fix)
+			if vErr != nil {
+				return vErr
+			}
+			logAudit(audit)
+
+			return nil
+		}
+	default:
+		downloader, closeFn, dErr := newDownloader(nCtx, cfg)
+		if dErr != nil && nCtx.Err() != nil {
+			log.Info().Msgf("image %s exit ...", f.mode)
 
-		done <- true
-	}()
+			return
+		}
+		if dErr != nil {
+			log.Panic().Err(dErr).Msg("failed to create image downloader")
 
-	go func() {
+			return
+		}
 		defer func() {
-			done <- true
+			if cErr := closeFn(); cErr != nil {
+				log.Error().Err(cErr).Msg("Failed to close image sources")
+			}
 		}()
-
-		report, err := importer.Import(pageConfig)
-		if err != nil {
-			log.Panic().Err(err).Msg("image import failed")
-
-			return
+		importer := cards.NewImageImporter(cardDao, store, downloader, cfg.Images)
+		run = func(ctx context.Context) error {
+			if f.mode == modeRefresh {
+				report, rErr := importer.Refresh(ctx, f.refreshFilter)
+				if rErr != nil && ctx.Err() == nil {
+					return rErr
+				}
+				log.Info().Msgf("Report %#v", report)
+				if rErr != nil {
+					log.Info().Msg("Image refresh interrupted")
+				}
+
+				return nil
+			}
+
+			if f.upgrade {
+				report, uErr := importer.UpgradeFallbacks(ctx)
+				if uErr != nil && ctx.Err() == nil {
+					return uErr
+				}
+				log.Info().Msgf("Report %#v", report)
+				if uErr != nil {
+					log.Info().Msg("Fallback upgrade interrupted")
+				}
+
+				return nil
+			}
+
+			report, rErr := importer.Import(ctx, f.pageConfig, f.imageFilter)
+			var interrupted *cards.InterruptedError
+			if rErr != nil && !errors.As(rErr, &interrupted) {
+				return rErr
+			}
+			log.Info().Msgf("Report %#v", report)
+			if interrupted != nil {
+				log.Info().Msgf("Image import interrupted, resume with --after-id %d", interrupted.ResumeAfterID)
+
+				return nil
+			}
+
+			return logMisses(cardDao)
 		}
-		log.Info().Msgf("Report %#v", report)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(nCtx)
 	}()
 
-	<-done
+	select {
+	case err = <-done:
+	case <-nCtx.Done():
+		// restore the default behavior, a second signal exits immediately
+		stop()
+		log.Info().Msgf("image %s exit after the running work ...", f.mode)
+		err = <-done
+	}
+
+	if err != nil && nCtx.Err() != nil {
+		log.Info().Err(err).Msgf("image %s interrupted", f.mode)
+
+		return
+	}
+
+	if err != nil {
+		log.Panic().Err(err).Msgf("image %s failed", f.mode)
+	}
 }
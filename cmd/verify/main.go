@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/logger"
+	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+	"github.com/konstantinfoerster/card-importer-go/internal/scryfall"
+	"github.com/konstantinfoerster/card-importer-go/internal/timer"
+	"github.com/rs/zerolog/log"
+)
+
+type arrayFlag []string
+
+func (a *arrayFlag) String() string {
+	return fmt.Sprintf("%v", *a)
+}
+
+func (a *arrayFlag) Set(value string) error {
+	*a = append(*a, value)
+
+	return nil
+}
+
+const usage = `Usage: card-verify-cli [options...]
+  --config path to the configuration file
+  --file path to local Scryfall bulk data json file e.g. default-cards.json or all-cards.json
+  --translations compare translations, should only be used with the all_cards bulk data file
+  --help prints help information
+`
+
+type flags struct {
+	file                string
+	compareTranslations bool
+}
+
+func setup() (flags, config.Config) {
+	logger.SetupConsoleLogger()
+
+	var configPaths arrayFlag
+	var f flags
+
+	flag.Var(&configPaths, "config", "path to the configuration files e.g. --config /config.yaml --config /secret.yaml")
+	flag.StringVar(&f.file, "file", "", "path to local Scryfall bulk data json file")
+	flag.BoolVar(&f.compareTranslations, "translations", false, "compare translations")
+	flag.Usage = func() { fmt.Print(usage) }
+	flag.Parse()
+
+	cfg, err := config.ReadConfigs(configPaths...)
+	if err != nil {
+		panic(err)
+	}
+
+	err = logger.SetLogLevel(cfg.Logging.LevelOrDefault())
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info().Msgf("OS\t\t %s", runtime.GOOS)
+	log.Info().Msgf("ARCH\t\t %s", runtime.GOARCH)
+	log.Info().Msgf("CPUs\t\t %d", runtime.NumCPU())
+
+	if f.file == "" {
+		panic("flag --file is required")
+	}
+	log.Info().Msgf("Using bulk data from file %s", f.file)
+
+	return f, cfg
+}
+
+func readSourceCards(file string) ([]*cards.Card, error) {
+	fp := filepath.Clean(file)
+	// #nosec G703 should be fine here
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s %w", fp, err)
+	}
+	defer aio.Close(f)
+
+	return scryfall.ReadCards(f)
+}
+
+func main() {
+	defer timer.TimeTrack(time.Now(), "verify")
+
+	f, cfg := setup()
+
+	conn, err := postgres.Connect(context.Background(), cfg.Database)
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to connect to the database")
+
+		return
+	}
+	defer func(toCloseFn func() error) {
+		cErr := toCloseFn()
+		if cErr != nil {
+			log.Panic().Err(cErr).Msg("Failed to close database connection")
+		}
+	}(conn.Close)
+
+	dbCards, err := cards.NewCardDao(conn).FindAllForVerify()
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to load cards from the database")
+
+		return
+	}
+	sourceCards, err := readSourceCards(f.file)
+	if err != nil {
+		log.Panic().Err(err).Msg("failed to read cards from the bulk data file")
+
+		return
+	}
+	log.Info().Msgf("Comparing %d database cards with %d source cards", len(dbCards), len(sourceCards))
+
+	report := cards.Verify(dbCards, sourceCards, f.compareTranslations)
+
+	for _, k := range report.MissingInSource {
+		log.Warn().Msgf("Card %s is missing in the source", k)
+	}
+	for _, k := range report.MissingInDatabase {
+		log.Warn().Msgf("Card %s is missing in the database", k)
+	}
+	for _, m := range report.KeyMismatches {
+		log.Warn().Msgf("Card %s is %s in the database but %s in the source", m.Name, m.Database, m.Source)
+	}
+	for _, k := range slices.SortedFunc(maps.Keys(report.Changes), func(a, b cards.CardKey) int {
+		return strings.Compare(a.String(), b.String())
+	}) {
+		log.Warn().Msgf("Card %s differs with changes %s", k, report.Changes[k].String())
+	}
+	for _, t := range report.MissingTranslations {
+		log.Warn().Msgf("Translation %s of card %s face %s is missing in the %s", t.Lang, t.Card, t.Face,
+			t.MissingIn)
+	}
+
+	log.Info().Msgf("Missing in source: %d, missing in database: %d, key mismatches: %d, changed: %d, "+
+		"missing translations: %d", len(report.MissingInSource), len(report.MissingInDatabase),
+		len(report.KeyMismatches), len(report.Changes), len(report.MissingTranslations))
+}
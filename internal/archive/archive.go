@@ -0,0 +1,179 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+)
+
+// Placeholders of the path template.
+const (
+	PlaceholderSet     = "{set}"
+	PlaceholderNumber  = "{number}"
+	PlaceholderLang    = "{lang}"
+	PlaceholderName    = "{name}"
+	PlaceholderVariant = "{variant}"
+)
+
+var mimeTypes = map[string]string{
+	".jpg":  web.MimeTypeJpeg,
+	".jpeg": web.MimeTypeJpeg,
+	".png":  web.MimeTypePng,
+	".gif":  web.MimeTypeGif,
+}
+
+type opener func() (io.ReadCloser, error)
+
+// Source Provides card images from a local directory or zip file. The path of an image is built from the configured
+// template, paths are compared case-insensitive.
+type Source struct {
+	template string
+	entries  map[string]opener
+	closer   io.Closer
+}
+
+// Open Indexes all images of the configured directory or zip file. A zip file is kept open until the source is closed.
+func Open(cfg config.ImageArchive) (*Source, error) {
+	if strings.TrimSpace(cfg.Path) == "" {
+		return nil, fmt.Errorf("missing image archive path")
+	}
+	root := filepath.Clean(strings.TrimSpace(cfg.Path))
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image archive %s %w", root, err)
+	}
+
+	s := &Source{template: cfg.TemplateOrDefault(), entries: map[string]opener{}}
+	if info.IsDir() {
+		err = s.indexDir(root)
+	} else {
+		err = s.indexZip(root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to index image archive %s %w", root, err)
+	}
+
+	return s, nil
+}
+
+func (s *Source) indexDir(root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isImage(p) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		s.entries[entryKey(filepath.ToSlash(rel))] = func() (io.ReadCloser, error) {
+			return os.Open(filepath.Clean(p))
+		}
+
+		return nil
+	})
+}
+
+func (s *Source) indexZip(file string) error {
+	r, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	s.closer = r
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || !isImage(f.Name) {
+			continue
+		}
+		s.entries[entryKey(f.Name)] = f.Open
+	}
+
+	return nil
+}
+
+// GetImage Returns the image that matches the filter. Variants other than normal are only found if the template
+// contains the variant placeholder.
+func (s *Source) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	variant := variantOrDefault(f.Variant)
+	if variant != cards.VariantNormal && !strings.Contains(s.template, PlaceholderVariant) {
+		return nil, fmt.Errorf("image archive has no images with variant %s %w", variant, cards.ErrImageNotFound)
+	}
+
+	p := s.Path(f)
+	open, ok := s.entries[entryKey(p)]
+	if !ok {
+		return nil, fmt.Errorf("image %s is not part of the image archive %w", p, cards.ErrImageNotFound)
+	}
+
+	file, err := open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image %s of the image archive %w", p, err)
+	}
+
+	return &cards.ImageResult{
+		File:     file,
+		MimeType: web.NewMimeType(mimeTypes[strings.ToLower(path.Ext(p))]),
+		Lang:     f.Lang,
+	}, nil
+}
+
+// Path Returns the path of the image inside the archive that matches the filter.
+func (s *Source) Path(f cards.Filter) string {
+	return strings.NewReplacer(
+		PlaceholderSet, f.SetCode,
+		PlaceholderNumber, f.Number,
+		PlaceholderLang, f.Lang,
+		PlaceholderName, f.Name,
+		PlaceholderVariant, variantOrDefault(f.Variant),
+	).Replace(s.template)
+}
+
+// Len Returns the amount of indexed images.
+func (s *Source) Len() int {
+	return len(s.entries)
+}
+
+// Close Closes the zip file, nothing to do for a directory.
+func (s *Source) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+
+	return s.closer.Close()
+}
+
+func variantOrDefault(variant string) string {
+	if variant == "" {
+		return cards.VariantNormal
+	}
+
+	return variant
+}
+
+func isImage(name string) bool {
+	_, ok := mimeTypes[strings.ToLower(path.Ext(filepath.ToSlash(name)))]
+
+	return ok
+}
+
+func entryKey(p string) string {
+	return strings.ToLower(path.Clean("/" + strings.TrimSpace(p)))
+}
@@ -0,0 +1,193 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/archive"
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/logger"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.SetupConsoleLogger()
+	err := logger.SetLogLevel("warn")
+	if err != nil {
+		fmt.Printf("Failed to set log level %v", err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+var files = map[string]string{
+	"10E/1_eng.jpg":   "10E 1 eng",
+	"10e/2_deu.PNG":   "10E 2 deu",
+	"10E/3_eng.txt":   "no image",
+	"m21/100_eng.gif": "M21 100 eng",
+}
+
+func createDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func createZip(t *testing.T) string {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), "images.zip")
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	return p
+}
+
+func TestGetImage(t *testing.T) {
+	sources := map[string]string{
+		"directory": createDir(t),
+		"zip":       createZip(t),
+	}
+	cases := []struct {
+		name     string
+		template string
+		filter   cards.Filter
+		want     string
+		wantMime string
+	}{
+		{
+			name:     "jpeg",
+			template: "{set}/{number}_{lang}.jpg",
+			filter:   cards.Filter{SetCode: "10E", Number: "1", Lang: "eng"},
+			want:     "10E 1 eng",
+			wantMime: web.MimeTypeJpeg,
+		},
+		{
+			name:     "path is case insensitive",
+			template: "{set}/{number}_{lang}.png",
+			filter:   cards.Filter{SetCode: "10E", Number: "2", Lang: "deu"},
+			want:     "10E 2 deu",
+			wantMime: web.MimeTypePng,
+		},
+		{
+			name:     "normal variant",
+			template: "{set}/{number}_{lang}.gif",
+			filter:   cards.Filter{SetCode: "M21", Number: "100", Lang: "eng", Variant: cards.VariantNormal},
+			want:     "M21 100 eng",
+			wantMime: web.MimeTypeGif,
+		},
+		{
+			name:     "variant placeholder",
+			template: "{set}/{number}_{lang}.{variant}",
+			filter:   cards.Filter{SetCode: "10E", Number: "1", Lang: "eng", Variant: "jpg"},
+			want:     "10E 1 eng",
+			wantMime: web.MimeTypeJpeg,
+		},
+	}
+	for sourceName, p := range sources {
+		for _, tc := range cases {
+			t.Run(sourceName+" "+tc.name, func(t *testing.T) {
+				source, err := archive.Open(config.ImageArchive{Path: p, Template: tc.template})
+				require.NoError(t, err)
+				defer source.Close()
+
+				result, err := source.GetImage(t.Context(), tc.filter)
+
+				require.NoError(t, err)
+				defer result.File.Close()
+				content, err := io.ReadAll(result.File)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, string(content))
+				assert.Equal(t, tc.wantMime, result.MimeType.Raw())
+				assert.Equal(t, tc.filter.Lang, result.Lang)
+			})
+		}
+	}
+}
+
+func TestGetImageNotFound(t *testing.T) {
+	sources := map[string]string{
+		"directory": createDir(t),
+		"zip":       createZip(t),
+	}
+	cases := []struct {
+		name   string
+		filter cards.Filter
+	}{
+		{
+			name:   "unknown number",
+			filter: cards.Filter{SetCode: "10E", Number: "4", Lang: "eng"},
+		},
+		{
+			name:   "unsupported file type",
+			filter: cards.Filter{SetCode: "10E", Number: "3", Lang: "eng"},
+		},
+		{
+			name:   "variant without placeholder",
+			filter: cards.Filter{SetCode: "10E", Number: "1", Lang: "eng", Variant: cards.VariantArtCrop},
+		},
+	}
+	for sourceName, p := range sources {
+		source, err := archive.Open(config.ImageArchive{Path: p, Template: "{set}/{number}_{lang}.jpg"})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = source.Close() })
+
+		assert.Equal(t, 3, source.Len())
+		for _, tc := range cases {
+			t.Run(sourceName+" "+tc.name, func(t *testing.T) {
+				_, err := source.GetImage(t.Context(), tc.filter)
+
+				require.ErrorIs(t, err, cards.ErrImageNotFound)
+			})
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	source, err := archive.Open(config.ImageArchive{
+		Path:     createDir(t),
+		Template: "{lang}/{set}/{number} {name} {variant}.jpg",
+	})
+	require.NoError(t, err)
+
+	p := source.Path(cards.Filter{SetCode: "10E", Number: "1", Lang: "deu", Name: "Fire"})
+
+	assert.Equal(t, "deu/10E/1 Fire normal.jpg", p)
+}
+
+func TestOpenFails(t *testing.T) {
+	_, err := archive.Open(config.ImageArchive{})
+	require.Error(t, err)
+
+	_, err = archive.Open(config.ImageArchive{Path: filepath.Join(t.TempDir(), "missing")})
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	noZip := filepath.Join(t.TempDir(), "images.zip")
+	require.NoError(t, os.WriteFile(noZip, []byte("no zip"), 0o600))
+	_, err = archive.Open(config.ImageArchive{Path: noZip})
+	require.Error(t, err)
+}
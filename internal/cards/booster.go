@@ -0,0 +1,191 @@
+package cards
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+// SealedProduct A product of a set that is sold sealed e.g. a booster box, a bundle or a precon deck.
+type SealedProduct struct {
+	MtgjsonID string
+	Name      string
+	Category  string // e.g. booster_box, bundle, deck
+	Subtype   string // e.g. draft, collector, default
+	Contents  string // JSON encoded content description from MTGJSON
+}
+
+func (p *SealedProduct) isValid() error {
+	if p.MtgjsonID == "" {
+		return fmt.Errorf("field 'mtgjsonId' must not be empty")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("field 'name' must not be empty in sealed product %s", p.MtgjsonID)
+	}
+
+	return nil
+}
+
+// Booster The configuration of a booster type e.g. draft, set or collector booster.
+type Booster struct {
+	Name     string
+	Variants []BoosterVariant
+	Sheets   []BoosterSheet
+}
+
+func (b *Booster) isValid() error {
+	if b.Name == "" {
+		return fmt.Errorf("field 'name' must not be empty")
+	}
+	if len(b.Variants) == 0 {
+		return fmt.Errorf("booster %s must at least have one variant", b.Name)
+	}
+
+	for _, v := range b.Variants {
+		for sheet := range v.Contents {
+			if b.sheet(sheet) == nil {
+				return fmt.Errorf("booster %s references unknown sheet %s", b.Name, sheet)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *Booster) sheet(name string) *BoosterSheet {
+	for i := range b.Sheets {
+		if b.Sheets[i].Name == name {
+			return &b.Sheets[i]
+		}
+	}
+
+	return nil
+}
+
+// BoosterVariant One possible content of a booster. The content is the amount of cards taken from each sheet.
+// The weight defines how likely the variant is compared to the other variants of the booster.
+type BoosterVariant struct {
+	Weight   int
+	Contents map[string]int
+}
+
+// BoosterSheet A print sheet cards are taken from. The weight of a card defines how likely the card is compared to
+// the other cards of the sheet.
+type BoosterSheet struct {
+	Name            string
+	Foil            bool
+	Fixed           bool // fixed sheets always contain all cards, the weight is the amount of copies
+	AllowDuplicates bool
+	BalanceColors   bool
+	Cards           []SheetCard
+}
+
+type SheetCard struct {
+	MtgjsonID string
+	Weight    int
+}
+
+// BoosterCard A card opened from a booster.
+type BoosterCard struct {
+	MtgjsonID string
+	Sheet     string
+	Foil      bool
+	Card      *Card // nil if the card is not imported
+}
+
+// Open Opens a virtual booster by picking a variant and drawing the cards from the sheets based on their weights.
+// Color balancing of sheets is not applied.
+func (b *Booster) Open(rnd *rand.Rand) ([]BoosterCard, error) {
+	variantWeights := make([]int, len(b.Variants))
+	for i, v := range b.Variants {
+		variantWeights[i] = v.Weight
+	}
+	pos, err := pickWeighted(rnd, variantWeights)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pick variant of booster %s %w", b.Name, err)
+	}
+	variant := b.Variants[pos]
+
+	// same order for the same random source
+	var sheetNames []string
+	for name := range variant.Contents {
+		sheetNames = append(sheetNames, name)
+	}
+	slices.Sort(sheetNames)
+
+	var result []BoosterCard
+	for _, name := range sheetNames {
+		sheet := b.sheet(name)
+		if sheet == nil {
+			return nil, fmt.Errorf("booster %s references unknown sheet %s", b.Name, name)
+		}
+
+		drawn, err := sheet.draw(rnd, variant.Contents[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to draw cards from sheet %s of booster %s %w", name, b.Name, err)
+		}
+		result = append(result, drawn...)
+	}
+
+	return result, nil
+}
+
+func (s *BoosterSheet) draw(rnd *rand.Rand, count int) ([]BoosterCard, error) {
+	newCard := func(c SheetCard) BoosterCard {
+		return BoosterCard{MtgjsonID: c.MtgjsonID, Sheet: s.Name, Foil: s.Foil}
+	}
+
+	var result []BoosterCard
+	if s.Fixed {
+		for _, c := range s.Cards {
+			for range c.Weight {
+				result = append(result, newCard(c))
+			}
+		}
+
+		return result, nil
+	}
+
+	weights := make([]int, len(s.Cards))
+	for i, c := range s.Cards {
+		weights[i] = c.Weight
+	}
+	for range count {
+		pos, err := pickWeighted(rnd, weights)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, newCard(s.Cards[pos]))
+
+		if !s.AllowDuplicates {
+			// a card can't be drawn twice from the same sheet
+			weights[pos] = 0
+		}
+	}
+
+	return result, nil
+}
+
+// pickWeighted Returns the position of a randomly picked weight. Higher weights are picked more likely.
+func pickWeighted(rnd *rand.Rand, weights []int) (int, error) {
+	total := 0
+	for _, w := range weights {
+		total += max(w, 0)
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("nothing left to pick from")
+	}
+
+	n := rnd.IntN(total)
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if n < w {
+			return i, nil
+		}
+		n -= w
+	}
+
+	return 0, fmt.Errorf("nothing left to pick from")
+}
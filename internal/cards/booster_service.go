@@ -0,0 +1,54 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// BoosterSimulator Opens virtual boosters based on the imported booster configurations.
+type BoosterSimulator interface {
+	Open(setCode string, name string) ([]BoosterCard, error)
+}
+
+type boosterSimulator struct {
+	setDao  *PostgresSetDao
+	cardDao *PostgresCardDao
+	rnd     *rand.Rand
+}
+
+func NewBoosterSimulator(setDao *PostgresSetDao, cardDao *PostgresCardDao, rnd *rand.Rand) BoosterSimulator {
+	return &boosterSimulator{
+		setDao:  setDao,
+		cardDao: cardDao,
+		rnd:     rnd,
+	}
+}
+
+// Open Opens the booster with the given name of the given set. Cards that are not imported are returned without
+// card details.
+func (s *boosterSimulator) Open(setCode string, name string) ([]BoosterCard, error) {
+	booster, err := s.setDao.FindBooster(setCode, name)
+	if err != nil {
+		return nil, err
+	}
+
+	opened, err := booster.Open(s.rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range opened {
+		c, err := s.cardDao.FindCardByMtgjsonID(opened[i].MtgjsonID)
+		if err != nil {
+			if errors.Is(err, ErrEntryNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to resolve card %s of booster %s %w", opened[i].MtgjsonID, name, err)
+		}
+		opened[i].Card = c
+	}
+
+	return opened, nil
+}
@@ -0,0 +1,95 @@
+package cards_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenBooster(t *testing.T) {
+	booster := cards.Booster{
+		Name: "draft",
+		Variants: []cards.BoosterVariant{
+			{Weight: 1, Contents: map[string]int{"common": 3, "land": 1, "token": 1}},
+		},
+		Sheets: []cards.BoosterSheet{
+			{
+				Name:  "common",
+				Cards: []cards.SheetCard{{MtgjsonID: "1", Weight: 1}, {MtgjsonID: "2", Weight: 5}, {MtgjsonID: "3", Weight: 1}},
+			},
+			{
+				Name:            "land",
+				Foil:            true,
+				AllowDuplicates: true,
+				Cards:           []cards.SheetCard{{MtgjsonID: "10", Weight: 1}},
+			},
+			{
+				Name:  "token",
+				Fixed: true,
+				Cards: []cards.SheetCard{{MtgjsonID: "20", Weight: 2}},
+			},
+		},
+	}
+
+	got, err := booster.Open(rand.New(rand.NewPCG(1, 2)))
+
+	require.NoError(t, err)
+	require.Len(t, got, 6)
+	var commons []string
+	for _, c := range got[:3] {
+		assert.Equal(t, "common", c.Sheet)
+		assert.False(t, c.Foil)
+		commons = append(commons, c.MtgjsonID)
+	}
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, commons, "commons must not contain duplicates")
+	assert.Equal(t, cards.BoosterCard{MtgjsonID: "10", Sheet: "land", Foil: true}, got[3])
+	assert.Equal(t, cards.BoosterCard{MtgjsonID: "20", Sheet: "token"}, got[4])
+	assert.Equal(t, cards.BoosterCard{MtgjsonID: "20", Sheet: "token"}, got[5])
+}
+
+func TestOpenBoosterIsReproducible(t *testing.T) {
+	booster := cards.Booster{
+		Name: "draft",
+		Variants: []cards.BoosterVariant{
+			{Weight: 1, Contents: map[string]int{"common": 2}},
+			{Weight: 1, Contents: map[string]int{"rare": 1}},
+		},
+		Sheets: []cards.BoosterSheet{
+			{Name: "common", Cards: []cards.SheetCard{{MtgjsonID: "1", Weight: 1}, {MtgjsonID: "2", Weight: 1}}},
+			{Name: "rare", Cards: []cards.SheetCard{{MtgjsonID: "3", Weight: 1}}},
+		},
+	}
+
+	first, err := booster.Open(rand.New(rand.NewPCG(7, 7)))
+	require.NoError(t, err)
+	second, err := booster.Open(rand.New(rand.NewPCG(7, 7)))
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestOpenBoosterFailsWhenSheetIsExhausted(t *testing.T) {
+	booster := cards.Booster{
+		Name:     "draft",
+		Variants: []cards.BoosterVariant{{Weight: 1, Contents: map[string]int{"common": 2}}},
+		Sheets:   []cards.BoosterSheet{{Name: "common", Cards: []cards.SheetCard{{MtgjsonID: "1", Weight: 1}}}},
+	}
+
+	_, err := booster.Open(rand.New(rand.NewPCG(1, 2)))
+
+	assert.ErrorContains(t, err, "failed to draw cards from sheet common")
+}
+
+func TestOpenBoosterFailsOnUnknownSheet(t *testing.T) {
+	booster := cards.Booster{
+		Name:     "draft",
+		Variants: []cards.BoosterVariant{{Weight: 1, Contents: map[string]int{"unknown": 1}}},
+	}
+
+	_, err := booster.Open(rand.New(rand.NewPCG(1, 2)))
+
+	assert.ErrorContains(t, err, "references unknown sheet unknown")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/corona10/goimagehash"
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
 )
 
@@ -105,6 +106,7 @@ func (c Card) String() string {
 // Face The face data of a card.
 type Face struct {
 	ID                PrimaryID
+	MtgjsonID         string // uuid of the face in the MTGJSON dataset
 	Name              string
 	Text              string
 	FlavorText        string
@@ -123,6 +125,9 @@ type Face struct {
 	Supertypes        []string // A list of card supertypes found before em-dash.
 	Subtypes          []string // A list of card subtypes found after em-dash.
 	Translations      []FaceTranslation
+	// KeepTranslations true if the source does not provide translations, the existing translations are kept and
+	// Translations is ignored. Otherwise, the existing translations are replaced with Translations.
+	KeepTranslations bool
 }
 
 // isSame Compares the identities of two faces.
@@ -219,6 +224,13 @@ func (f Face) Diff(other *Face) *Changeset {
 			To:   other.Toughness,
 		})
 	}
+	// other sources than MTGJSON do not know the uuid, keep the existing one in that case
+	if other.MtgjsonID != "" && f.MtgjsonID != other.MtgjsonID {
+		changes.Add("MtgjsonID", Changes{
+			From: f.MtgjsonID,
+			To:   other.MtgjsonID,
+		})
+	}
 
 	return changes
 }
@@ -355,6 +367,7 @@ func (v *Colors) UnmarshalJSON(data []byte) error {
 type Image struct {
 	ID        PrimaryID
 	Lang      string
+	Variant   string // e.g. normal, small or art_crop
 	CardID    PrimaryID
 	FaceID    PrimaryID
 	ImagePath string
@@ -363,6 +376,56 @@ type Image struct {
 	PHash2    uint64
 	PHash3    uint64
 	PHash4    uint64
+	AHash     []uint64 // average hash, empty if not computed
+	DHash     []uint64 // difference hash, empty if not computed
+	// SourceLang language of the stored image, differs from Lang if the image of the fallback language is stored.
+	SourceLang string
+	Fallback   bool
+	// ETag and LastModified validators of the downloaded image, used to request the image only if it has changed.
+	ETag         string
+	LastModified string
+	// BlobID shared file of a content addressed image, not valid if the image has its own file.
+	BlobID PrimaryID
+	// Verified true if the source confirmed the language, set and number of the stored image.
+	Verified bool
+	// Mismatch why the image of the requested language was rejected, e.g. the source returned another printing.
+	Mismatch string
+	// Renditions derived from the image e.g. thumbnails, only set on import.
+	Renditions []Rendition
+}
+
+// Rendition A version of a card image that is derived from the stored image e.g. a thumbnail.
+type Rendition struct {
+	ID        PrimaryID
+	ImageID   PrimaryID
+	Name      string // e.g. w146 or gray
+	ImagePath string
+	MimeType  string
+	Width     int
+	Height    int
+}
+
+// PerceptionHash Returns the 256 bit extended perception hash.
+func (img *Image) PerceptionHash() *goimagehash.ExtImageHash {
+	return goimagehash.NewExtImageHash([]uint64{img.PHash1, img.PHash2, img.PHash3, img.PHash4}, goimagehash.PHash, 256)
+}
+
+// AverageHash Returns the average hash or nil if not computed.
+func (img *Image) AverageHash() *goimagehash.ExtImageHash {
+	if len(img.AHash) == 0 {
+		return nil
+	}
+
+	return goimagehash.NewExtImageHash(img.AHash, goimagehash.AHash, len(img.AHash)*64)
+}
+
+// DifferenceHash Returns the difference hash or nil if not computed.
+func (img *Image) DifferenceHash() *goimagehash.ExtImageHash {
+	if len(img.DHash) == 0 {
+		return nil
+	}
+
+	return goimagehash.NewExtImageHash(img.DHash, goimagehash.DHash, len(img.DHash)*64)
 }
 
 func (img *Image) getFilePrefix() (string, error) {
@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -62,14 +64,140 @@ func (d *PostgresCardDao) FindUniqueCard(set string, number string) (*Card, erro
 	return &c, nil
 }
 
-// Paged Returns cards limited by the given size for the given page. The page parameter is one based.
-func (d *PostgresCardDao) Paged(page int, size int) ([]Card, error) {
-	page--
-	if page < 0 {
-		page = 0
+// FindCardByMtgjsonID Returns the card that contains the face with the given MTGJSON uuid.
+func (d *PostgresCardDao) FindCardByMtgjsonID(mtgjsonID string) (*Card, error) {
+	query := `
+		SELECT
+			c.id, c.name, c.number, c.rarity, c.border, c.layout, c.card_set_code
+		FROM 
+			card AS c
+		JOIN
+			card_face AS cf
+		ON
+			c.id = cf.card_id
+		WHERE 
+			cf.mtgjson_id = $1
+		LIMIT 1`
+
+	var c Card
+	err := d.db.Conn.QueryRow(context.TODO(), query, mtgjsonID).Scan(&c.ID, &c.Name, &c.Number, &c.Rarity, &c.Border,
+		&c.Layout, &c.CardSetCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute card select by mtgjson id %s %w", mtgjsonID, err)
 	}
 
-	offset := page * size
+	return &c, nil
+}
+
+// CardFilter Limits the cards of a query, empty fields match all cards.
+type CardFilter struct {
+	SetCodes      []string
+	Numbers       []string
+	Rarities      []string
+	ReleasedAfter time.Time // cards of sets released after the date, sets without release date don't match
+}
+
+// cardFilterCondition Matches the cards of the filter, the filter values are the parameters $1 to $4 and the card
+// set must be joined as cs.
+const cardFilterCondition = `
+			(cardinality($1::VARCHAR[]) = 0 OR c.card_set_code = ANY($1)) AND
+			(cardinality($2::VARCHAR[]) = 0 OR c.number = ANY($2)) AND
+			(cardinality($3::VARCHAR[]) = 0 OR c.rarity::VARCHAR = ANY($3)) AND
+			($4::DATE IS NULL OR cs.released > $4)`
+
+// imageFilterCondition Matches the images of the sets and languages, the values are the parameters $1 and $2 and the
+// card must be joined as c.
+const imageFilterCondition = `(cardinality($1::VARCHAR[]) = 0 OR c.card_set_code = ANY($1)) AND
+			(cardinality($2::VARCHAR[]) = 0 OR ci.lang_lang = ANY($2))`
+
+func cardFilterArgs(filter CardFilter) []any {
+	var releasedAfter *time.Time
+	if !filter.ReleasedAfter.IsZero() {
+		releasedAfter = &filter.ReleasedAfter
+	}
+
+	return []any{nonNil(filter.SetCodes), nonNil(filter.Numbers), nonNil(filter.Rarities), releasedAfter}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// CountMatching Returns the amount of cards that match the filter.
+func (d *PostgresCardDao) CountMatching(filter CardFilter) (int, error) {
+	query := `
+		SELECT
+			count(c.id)
+		FROM
+			card AS c
+		LEFT JOIN
+			card_set AS cs ON cs.code = c.card_set_code
+		WHERE` + cardFilterCondition
+
+	var count int
+	if err := d.db.Conn.QueryRow(context.TODO(), query, cardFilterArgs(filter)...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute filtered card count %w", err)
+	}
+
+	return count, nil
+}
+
+// CardResult A streamed card or the error that stopped the stream.
+type CardResult struct {
+	Result *Card
+	Err    error
+}
+
+// StreamCards Streams the cards that match the filter and have an id greater than afterID ordered by id. The cards
+// are loaded in batches of the given size, every batch continues after the last id of the previous batch, so
+// cards that are added during the stream don't shift the following batches.
+func (d *PostgresCardDao) StreamCards(ctx context.Context, afterID int64, batchSize int,
+	filter CardFilter) <-chan CardResult {
+	c := make(chan CardResult)
+
+	go func() {
+		defer close(c)
+
+		lastID := afterID
+		for {
+			batch, err := d.PagedAfter(ctx, lastID, batchSize, filter)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+				case c <- CardResult{Result: nil, Err: err}:
+				}
+
+				return
+			}
+			if len(batch) == 0 {
+				return
+			}
+
+			for idx := range batch {
+				select {
+				case <-ctx.Done():
+					return
+				case c <- CardResult{Result: &batch[idx], Err: nil}:
+				}
+			}
+			lastID = batch[len(batch)-1].ID.Get()
+		}
+	}()
+
+	return c
+}
+
+// PagedAfter Returns cards that match the filter and have an id greater than afterID ordered by id, limited by the
+// given size.
+func (d *PostgresCardDao) PagedAfter(ctx context.Context, afterID int64, size int, filter CardFilter) ([]Card, error) {
 	query := `
 		SELECT
 			c.id, c.card_set_code AS cardSetCode, c.name, c.number, c.border, c.rarity, c.layout, 
@@ -80,14 +208,20 @@ func (d *PostgresCardDao) Paged(page int, size int) ([]Card, error) {
 			card_face AS cf
 		ON
 			c.id = cf.card_id
+		LEFT JOIN
+			card_set AS cs
+		ON
+			cs.code = c.card_set_code
+		WHERE` + cardFilterCondition + ` AND
+			c.id > $5
 		GROUP BY
 			c.id
 		ORDER BY
-			cardSetCode
-		LIMIT $1
-		OFFSET $2`
+			c.id
+		LIMIT $6`
 
-	rows, err := d.db.Conn.Query(context.TODO(), query, size, offset)
+	args := append(cardFilterArgs(filter), afterID, size)
+	rows, err := d.db.Conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute paged card face select %w", err)
 	}
@@ -111,6 +245,66 @@ func (d *PostgresCardDao) Paged(page int, size int) ([]Card, error) {
 	return result, nil
 }
 
+// FindAllForVerify Returns all cards with the face name, mana cost, type line and the translation names and
+// languages of each face. Other fields are not loaded.
+func (d *PostgresCardDao) FindAllForVerify() ([]*Card, error) {
+	query := `
+		SELECT
+			c.id, c.card_set_code, c.name, c.number, cf.id, cf.name, COALESCE(cf.mana_cost, ''),
+			COALESCE(cf.type_line, ''), COALESCE(ct.name, ''), COALESCE(ct.lang_lang, '')
+		FROM
+			card AS c
+		JOIN
+			card_face AS cf
+		ON
+			c.id = cf.card_id
+		LEFT JOIN
+			card_translation AS ct
+		ON
+			cf.id = ct.face_id
+		ORDER BY
+			c.id, cf.id, ct.lang_lang`
+
+	rows, err := d.db.Conn.Query(context.TODO(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute card verify select %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Card
+	var card *Card
+	var face *Face
+	for rows.Next() {
+		var c Card
+		var f Face
+		var t FaceTranslation
+		err := rows.Scan(&c.ID, &c.CardSetCode, &c.Name, &c.Number, &f.ID, &f.Name, &f.ManaCost, &f.TypeLine, &t.Name,
+			&t.Lang)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute card scan after verify select %w", err)
+		}
+
+		if card == nil || card.ID != c.ID {
+			card = &c
+			result = append(result, card)
+			face = nil
+		}
+		if face == nil || face.ID != f.ID {
+			face = &f
+			face.Translations = []FaceTranslation{}
+			card.Faces = append(card.Faces, face)
+		}
+		if t.Lang != "" {
+			face.Translations = append(face.Translations, t)
+		}
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read card verify result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
 // CreateCard Creates a new card. Will return an error if the card already exists.
 func (d *PostgresCardDao) CreateCard(c *Card) error {
 	query := `
@@ -163,7 +357,8 @@ func (d *PostgresCardDao) FindAssignedFaces(cardID int64) ([]*Face, error) {
 	query := `
 		SELECT
 			id, name, text, flavor_text, type_line, converted_mana_cost, colors, artist,
-			hand_modifier, life_modifier, loyalty, mana_cost, multiverse_id, power, toughness
+			hand_modifier, life_modifier, loyalty, mana_cost, multiverse_id, power, toughness,
+			COALESCE(mtgjson_id, '')
 		FROM
 			card_face
 		WHERE
@@ -182,7 +377,7 @@ func (d *PostgresCardDao) FindAssignedFaces(cardID int64) ([]*Face, error) {
 		err := rows.Scan(
 			&entry.ID, &entry.Name, &entry.Text, &entry.FlavorText, &entry.TypeLine, &entry.ConvertedManaCost,
 			&entry.Colors, &entry.Artist, &entry.HandModifier, &entry.LifeModifier, &entry.Loyalty, &entry.ManaCost,
-			&entry.MultiverseID, &entry.Power, &entry.Toughness)
+			&entry.MultiverseID, &entry.Power, &entry.Toughness, &entry.MtgjsonID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute face scan after select %w", err)
 		}
@@ -202,10 +397,11 @@ func (d *PostgresCardDao) AddFace(cardID int64, f *Face) error {
 		INSERT INTO 
 			card_face (
 				name, text, flavor_text, type_line, converted_mana_cost, colors, artist,
-				hand_modifier, life_modifier, loyalty, mana_cost, multiverse_id, power, toughness, card_id
+				hand_modifier, life_modifier, loyalty, mana_cost, multiverse_id, power, toughness, card_id,
+				mtgjson_id
 			) 
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, '')
 		)
 		RETURNING
 			id`
@@ -213,7 +409,8 @@ func (d *PostgresCardDao) AddFace(cardID int64, f *Face) error {
 	var id int64
 	err := d.db.Conn.QueryRow(context.TODO(), query,
 		f.Name, f.Text, f.FlavorText, f.TypeLine, f.ConvertedManaCost, f.Colors, f.Artist,
-		f.HandModifier, f.LifeModifier, f.Loyalty, f.ManaCost, f.MultiverseID, f.Power, f.Toughness, cardID).Scan(&id)
+		f.HandModifier, f.LifeModifier, f.Loyalty, f.ManaCost, f.MultiverseID, f.Power, f.Toughness, cardID,
+		f.MtgjsonID).Scan(&id)
 	if err != nil {
 		return fmt.Errorf("failed to execute card face insert %w", err)
 	}
@@ -230,7 +427,8 @@ func (d *PostgresCardDao) UpdateFace(f *Face) error {
 		SET
 			name = $1, text = $2, flavor_text = $3, type_line = $4, converted_mana_cost = $5,
 			colors = $6, artist = $7, hand_modifier = $8, life_modifier = $9, loyalty = $10, 
-			mana_cost = $11, multiverse_id = $12, power = $13, toughness = $14
+			mana_cost = $11, multiverse_id = $12, power = $13, toughness = $14,
+			mtgjson_id = COALESCE(NULLIF($16, ''), mtgjson_id)
 		WHERE
 			id = $15`
 
@@ -238,7 +436,7 @@ func (d *PostgresCardDao) UpdateFace(f *Face) error {
 		f.Name, f.Text, f.FlavorText, f.TypeLine, f.ConvertedManaCost,
 		f.Colors, f.Artist, f.HandModifier, f.LifeModifier, f.Loyalty,
 		f.ManaCost, f.MultiverseID, f.Power, f.Toughness,
-		f.ID)
+		f.ID, f.MtgjsonID)
 	if err != nil {
 		return fmt.Errorf("failed to execute card face update %w", err)
 	}
@@ -437,17 +635,17 @@ func (d *PostgresCardDao) Count() (int, error) {
 	return count, nil
 }
 
-// IsImagePresent Checks if the card image with the specified id and language exist.
-func (d *PostgresCardDao) IsImagePresent(ctx context.Context, faceID int64, lang string) (bool, error) {
+// IsImagePresent Checks if the card image with the specified id, language and variant exist.
+func (d *PostgresCardDao) IsImagePresent(ctx context.Context, faceID int64, lang, variant string) (bool, error) {
 	query := `
 		SELECT
 			count(*) > 0
 		FROM 
 			card_image
 		WHERE
-			lang_lang = $1 AND face_id = $2`
+			lang_lang = $1 AND face_id = $2 AND variant = $3`
 	var isPresent bool
-	err := d.db.Conn.QueryRow(ctx, query, lang, faceID).Scan(&isPresent)
+	err := d.db.Conn.QueryRow(ctx, query, lang, faceID, variant).Scan(&isPresent)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
@@ -470,19 +668,38 @@ func (d *PostgresCardDao) CountImages() (int, error) {
 	return count, nil
 }
 
-// AddImage Creates a new card image.
+// AddImage Creates a new card image together with its renditions.
 func (d *PostgresCardDao) AddImage(ctx context.Context, img *Image) error {
+	return d.withTransaction(func(txDao *PostgresCardDao) error {
+		if err := txDao.addImage(ctx, img); err != nil {
+			return err
+		}
+
+		return txDao.addRenditions(ctx, img)
+	})
+}
+
+func (d *PostgresCardDao) addImage(ctx context.Context, img *Image) error {
 	query := `
 		INSERT INTO
 			card_image (
 				image_path, lang_lang, card_id, face_id, mime_type, 
-                phash1, phash2, phash3, phash4
+                phash1, phash2, phash3, phash4, variant, ahash, dhash, source_lang, fallback, etag, last_modified,
+                blob_id, verified, mismatch
 			) 
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
 		)
 		RETURNING
 			id`
+	variant := img.Variant
+	if variant == "" {
+		variant = VariantNormal
+	}
+	sourceLang := img.SourceLang
+	if sourceLang == "" {
+		sourceLang = img.Lang
+	}
 	var id int64
 	err := d.db.Conn.QueryRow(ctx, query,
 		img.ImagePath, img.Lang, img.CardID, img.FaceID, img.MimeType,
@@ -490,6 +707,16 @@ func (d *PostgresCardDao) AddImage(ctx context.Context, img *Image) error {
 		fmt.Sprintf("%064b", img.PHash2),
 		fmt.Sprintf("%064b", img.PHash3),
 		fmt.Sprintf("%064b", img.PHash4),
+		variant,
+		toBitString(img.AHash),
+		toBitString(img.DHash),
+		sourceLang,
+		img.Fallback,
+		img.ETag,
+		img.LastModified,
+		img.BlobID,
+		img.Verified,
+		img.Mismatch,
 	).Scan(&id)
 	if err != nil {
 		return fmt.Errorf("failed to execute card insert %w", err)
@@ -499,15 +726,96 @@ func (d *PostgresCardDao) AddImage(ctx context.Context, img *Image) error {
 	return nil
 }
 
+func (d *PostgresCardDao) addRenditions(ctx context.Context, img *Image) error {
+	for idx := range img.Renditions {
+		r := &img.Renditions[idx]
+		r.ImageID = img.ID
+		if err := d.addRendition(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *PostgresCardDao) addRendition(ctx context.Context, r *Rendition) error {
+	query := `
+		INSERT INTO
+			card_image_rendition (
+				card_image_id, name, image_path, mime_type, width, height
+			) 
+		VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		RETURNING
+			id`
+	var id int64
+	err := d.db.Conn.QueryRow(ctx, query, r.ImageID, r.Name, r.ImagePath, r.MimeType, r.Width, r.Height).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to execute card image rendition insert %w", err)
+	}
+	r.ID = NewPrimaryID(id)
+
+	return nil
+}
+
+// FindRenditions Returns the renditions of the card image with the given id, an id of 0 matches all images.
+func (d *PostgresCardDao) FindRenditions(ctx context.Context, imageID int64) ([]*Rendition, error) {
+	query := `
+		SELECT
+			id, card_image_id, name, image_path, mime_type, width, height
+		FROM
+			card_image_rendition
+		WHERE
+			($1 = 0 OR card_image_id = $1)
+		ORDER BY
+			card_image_id, name
+        `
+	rows, err := d.db.Conn.Query(ctx, query, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select on card_image_rendition %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Rendition
+	for rows.Next() {
+		var r Rendition
+		if err := rows.Scan(&r.ID, &r.ImageID, &r.Name, &r.ImagePath, &r.MimeType, &r.Width, &r.Height); err != nil {
+			return nil, fmt.Errorf("failed to execute select on card_image_rendition %w", err)
+		}
+
+		result = append(result, &r)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read card image rendition result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
 func (d *PostgresCardDao) GetImages() ([]*Image, error) {
+	return d.FindImages(context.TODO(), nil, nil)
+}
+
+// FindImages Returns all card images of the given sets and languages, empty set codes or languages match all.
+func (d *PostgresCardDao) FindImages(ctx context.Context, setCodes, langs []string) ([]*Image, error) {
 	query := `
 		SELECT
-			id, image_path, card_id, face_id, mime_type, phash1, phash2,
-            phash3, phash4, lang_lang
+			ci.id, ci.image_path, ci.card_id, ci.face_id, ci.mime_type, ci.phash1, ci.phash2,
+            ci.phash3, ci.phash4, ci.lang_lang, ci.variant, ci.ahash, ci.dhash,
+            COALESCE(ci.source_lang, ci.lang_lang), ci.fallback, ci.etag, ci.last_modified, ci.blob_id,
+            ci.verified, ci.mismatch
 		FROM
-			card_image
+			card_image AS ci
+		JOIN
+			card AS c ON c.id = ci.card_id
+		WHERE
+			` + imageFilterCondition + `
+		ORDER BY
+			ci.id
         `
-	rows, err := d.db.Conn.Query(context.TODO(), query)
+	rows, err := d.db.Conn.Query(ctx, query, nonNil(setCodes), nonNil(langs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute select on card_image %w", err)
 	}
@@ -520,16 +828,21 @@ func (d *PostgresCardDao) GetImages() ([]*Image, error) {
 		var phash2 pgtype.Bits
 		var phash3 pgtype.Bits
 		var phash4 pgtype.Bits
+		var ahash pgtype.Bits
+		var dhash pgtype.Bits
 		rErr := rows.Scan(&img.ID, &img.ImagePath, &img.CardID,
-			&img.FaceID, &img.MimeType, &phash1, &phash2, &phash3, &phash4, &img.Lang)
+			&img.FaceID, &img.MimeType, &phash1, &phash2, &phash3, &phash4, &img.Lang, &img.Variant, &ahash, &dhash,
+			&img.SourceLang, &img.Fallback, &img.ETag, &img.LastModified, &img.BlobID, &img.Verified, &img.Mismatch)
 		if rErr != nil {
 			return nil, fmt.Errorf("failed to execute select on card_image %w", rErr)
 		}
 
-		img.PHash1 = binary.BigEndian.Uint64(phash1.Bytes)
-		img.PHash2 = binary.BigEndian.Uint64(phash2.Bytes)
-		img.PHash3 = binary.BigEndian.Uint64(phash3.Bytes)
-		img.PHash4 = binary.BigEndian.Uint64(phash4.Bytes)
+		img.PHash1 = firstBlock(phash1)
+		img.PHash2 = firstBlock(phash2)
+		img.PHash3 = firstBlock(phash3)
+		img.PHash4 = firstBlock(phash4)
+		img.AHash = fromBits(ahash)
+		img.DHash = fromBits(dhash)
 
 		result = append(result, &img)
 	}
@@ -541,26 +854,336 @@ func (d *PostgresCardDao) GetImages() ([]*Image, error) {
 	return result, nil
 }
 
-func (d *PostgresCardDao) UpdateHashes(
-	id int64, phash1 uint64, phash2 uint64, phash3 uint64, phash4 uint64) error {
-	nPhash1 := fmt.Sprintf("%064b", phash1)
-	maxLength := 64
-	if len(nPhash1) != maxLength {
-		return fmt.Errorf("phash1 %s must have a length of 64, but got %d", nPhash1, len(nPhash1))
+// FindImageMiss Returns the miss of the card face image with the given language and variant.
+// If no result is found ErrEntryNotFound is returned.
+func (d *PostgresCardDao) FindImageMiss(ctx context.Context, faceID int64, lang, variant string) (*ImageMiss, error) {
+	query := `
+		SELECT
+			id, card_id, face_id, lang_lang, variant, reason, attempts, last_attempt, next_retry
+		FROM
+			card_image_miss
+		WHERE
+			face_id = $1 AND lang_lang = $2 AND variant = $3`
+
+	var m ImageMiss
+	err := d.db.Conn.QueryRow(ctx, query, faceID, lang, variant).Scan(&m.ID, &m.CardID, &m.FaceID, &m.Lang,
+		&m.Variant, &m.Reason, &m.Attempts, &m.LastAttempt, &m.NextRetry)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute select on card_image_miss %w", err)
+	}
+
+	return &m, nil
+}
+
+// SaveImageMiss Creates the miss or replaces the existing miss of the same face, language and variant.
+func (d *PostgresCardDao) SaveImageMiss(ctx context.Context, m *ImageMiss) error {
+	query := `
+		INSERT INTO
+			card_image_miss (
+				card_id, face_id, lang_lang, variant, reason, attempts, last_attempt, next_retry
+			)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+		ON CONFLICT (face_id, lang_lang, variant) DO UPDATE SET
+			card_id = EXCLUDED.card_id,
+			reason = EXCLUDED.reason,
+			attempts = EXCLUDED.attempts,
+			last_attempt = EXCLUDED.last_attempt,
+			next_retry = EXCLUDED.next_retry
+		RETURNING
+			id`
+	var id int64
+	err := d.db.Conn.QueryRow(ctx, query, m.CardID, m.FaceID, m.Lang, m.Variant, m.Reason, m.Attempts,
+		m.LastAttempt, m.NextRetry).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to execute card image miss insert %w", err)
+	}
+	m.ID = NewPrimaryID(id)
+
+	return nil
+}
+
+// DeleteImageMiss Deletes the miss with the given id.
+func (d *PostgresCardDao) DeleteImageMiss(ctx context.Context, id int64) error {
+	_, err := d.db.Conn.Exec(ctx, "DELETE FROM card_image_miss WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete card image miss %d %w", id, err)
+	}
+
+	return nil
+}
+
+// CountImageMisses Returns the amount of missing images grouped by reason and set.
+func (d *PostgresCardDao) CountImageMisses(ctx context.Context) ([]ImageMissCount, error) {
+	query := `
+		SELECT
+			m.reason, c.card_set_code, count(m.id)
+		FROM
+			card_image_miss AS m
+		JOIN
+			card AS c ON c.id = m.card_id
+		GROUP BY
+			m.reason, c.card_set_code
+		ORDER BY
+			m.reason, c.card_set_code`
+	rows, err := d.db.Conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute count on card_image_miss %w", err)
+	}
+	defer rows.Close()
+
+	var result []ImageMissCount
+	for rows.Next() {
+		var c ImageMissCount
+		if err := rows.Scan(&c.Reason, &c.SetCode, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to execute count on card_image_miss %w", err)
+		}
+
+		result = append(result, c)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read card image miss result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// StoredImage A stored card image and the filter to download the image of the card language.
+type StoredImage struct {
+	Image  *Image
+	Filter Filter
+	Layout string // layout of the card e.g. TRANSFORM
+}
+
+// FindFallbackImages Returns all card images that are stored with the image of another language.
+func (d *PostgresCardDao) FindFallbackImages(ctx context.Context) ([]StoredImage, error) {
+	return d.findStoredImages(ctx, "ci.fallback")
+}
+
+// FindStoredImages Returns all card images of the given sets and languages, empty set codes or languages match all.
+func (d *PostgresCardDao) FindStoredImages(ctx context.Context, setCodes, langs []string) ([]StoredImage, error) {
+	return d.findStoredImages(ctx, imageFilterCondition, nonNil(setCodes), nonNil(langs))
+}
+
+// FindHashedImages Returns all card images with a perception hash.
+func (d *PostgresCardDao) FindHashedImages(ctx context.Context) ([]StoredImage, error) {
+	return d.findStoredImages(ctx, "ci.phash1 IS NOT NULL AND ci.phash2 IS NOT NULL AND ci.phash3 IS NOT NULL AND "+
+		"ci.phash4 IS NOT NULL")
+}
+
+func (d *PostgresCardDao) findStoredImages(ctx context.Context, condition string, args ...any) ([]StoredImage,
+	error) {
+	query := `
+		SELECT
+			ci.id, ci.image_path, ci.card_id, ci.face_id, ci.mime_type, ci.lang_lang, ci.variant,
+			COALESCE(ci.source_lang, ci.lang_lang), ci.fallback, ci.etag, ci.last_modified, ci.blob_id,
+			ci.verified, ci.mismatch, ci.phash1, ci.phash2, ci.phash3, ci.phash4, c.card_set_code, c.number,
+			COALESCE(cf.name, c.name), c.layout
+		FROM
+			card_image AS ci
+		JOIN
+			card AS c ON c.id = ci.card_id
+		LEFT JOIN
+			card_face AS cf ON cf.id = ci.face_id
+		WHERE
+			` + condition + `
+		ORDER BY
+			ci.id
+        `
+	rows, err := d.db.Conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select on card_image %w", err)
+	}
+	defer rows.Close()
+
+	var result []StoredImage
+	for rows.Next() {
+		var img Image
+		var f Filter
+		var layout string
+		var phash1, phash2, phash3, phash4 pgtype.Bits
+		if err := rows.Scan(&img.ID, &img.ImagePath, &img.CardID, &img.FaceID, &img.MimeType, &img.Lang,
+			&img.Variant, &img.SourceLang, &img.Fallback, &img.ETag, &img.LastModified, &img.BlobID, &img.Verified,
+			&img.Mismatch, &phash1, &phash2, &phash3, &phash4, &f.SetCode, &f.Number, &f.Name, &layout); err != nil {
+			return nil, fmt.Errorf("failed to execute select on card_image %w", err)
+		}
+		img.PHash1 = firstBlock(phash1)
+		img.PHash2 = firstBlock(phash2)
+		img.PHash3 = firstBlock(phash3)
+		img.PHash4 = firstBlock(phash4)
+		f.Lang = img.Lang
+		f.Variant = img.Variant
+
+		result = append(result, StoredImage{Image: &img, Filter: f, Layout: layout})
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read card image result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// ReplaceImage Updates the file, blob, hashes, language, verification and validators of the card image with the id of
+// the given image and replaces its renditions.
+func (d *PostgresCardDao) ReplaceImage(ctx context.Context, img *Image) error {
+	query := `
+		UPDATE
+			card_image 
+		SET
+			image_path=$2,
+			mime_type=$3,
+			source_lang=$4,
+			fallback=$5,
+			etag=$6,
+			last_modified=$7,
+			blob_id=$8,
+			verified=$9,
+			mismatch=$10
+        WHERE
+			id = $1`
+
+	return d.withTransaction(func(txDao *PostgresCardDao) error {
+		ct, err := txDao.db.Conn.Exec(ctx, query, img.ID, img.ImagePath, img.MimeType, img.SourceLang, img.Fallback,
+			img.ETag, img.LastModified, img.BlobID, img.Verified, img.Mismatch)
+		if err != nil {
+			return fmt.Errorf("failed to execute card image update %w", err)
+		}
+		if ra := ct.RowsAffected(); ra != 1 {
+			return fmt.Errorf("%d card image updated but expected to update card image with "+
+				"id %d", ra, img.ID.Get())
+		}
+
+		if err := txDao.UpdateHashes(ctx, img); err != nil {
+			return err
+		}
+
+		_, err = txDao.db.Conn.Exec(ctx, "DELETE FROM card_image_rendition WHERE card_image_id = $1", img.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete renditions of card image %d %w", img.ID.Get(), err)
+		}
+		return txDao.addRenditions(ctx, img)
+	})
+}
+
+// AcquireBlob Creates the blob with a reference count of one or increments the reference count of the existing blob
+// with the same sha256. The id, path, mime type and reference count of the stored blob are set on the given blob.
+// Within a transaction the blob row stays locked until the transaction ends.
+func (d *PostgresCardDao) AcquireBlob(ctx context.Context, b *Blob) error {
+	query := `
+		INSERT INTO
+			image_blob (
+				sha256, image_path, mime_type, ref_count
+			)
+		VALUES (
+			$1, $2, $3, 1
+		)
+		ON CONFLICT (sha256) DO UPDATE SET
+			ref_count = image_blob.ref_count + 1
+		RETURNING
+			id, image_path, mime_type, ref_count`
+	err := d.db.Conn.QueryRow(ctx, query, b.SHA256, b.ImagePath, b.MimeType).Scan(&b.ID, &b.ImagePath, &b.MimeType,
+		&b.RefCount)
+	if err != nil {
+		return fmt.Errorf("failed to execute image blob insert %w", err)
 	}
-	nPhash2 := fmt.Sprintf("%064b", phash2)
-	if len(nPhash2) != maxLength {
-		return fmt.Errorf("phash2 %s must have a length of 64, but got %d", nPhash2, len(nPhash2))
+
+	return nil
+}
+
+// ReleaseBlob Decrements the reference count of the blob with the given id and deletes the blob once it has no
+// references left. Within a transaction the blob row stays locked until the transaction ends.
+func (d *PostgresCardDao) ReleaseBlob(ctx context.Context, id int64) (*Blob, error) {
+	query := `
+		UPDATE
+			image_blob
+		SET
+			ref_count = ref_count - 1
+		WHERE
+			id = $1
+		RETURNING
+			id, sha256, image_path, mime_type, ref_count`
+	var b Blob
+	err := d.db.Conn.QueryRow(ctx, query, id).Scan(&b.ID, &b.SHA256, &b.ImagePath, &b.MimeType, &b.RefCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("image blob %d %w", id, ErrEntryNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to execute image blob update %w", err)
+	}
+
+	if b.RefCount == 0 {
+		if _, err := d.db.Conn.Exec(ctx, "DELETE FROM image_blob WHERE id = $1", id); err != nil {
+			return nil, fmt.Errorf("failed to delete image blob %d %w", id, err)
+		}
+	}
+
+	return &b, nil
+}
+
+// FindBlob Returns the blob with the given id. If no result is found ErrEntryNotFound is returned.
+func (d *PostgresCardDao) FindBlob(ctx context.Context, id int64) (*Blob, error) {
+	query := `
+		SELECT
+			id, sha256, image_path, mime_type, ref_count
+		FROM
+			image_blob
+		WHERE
+			id = $1`
+	var b Blob
+	err := d.db.Conn.QueryRow(ctx, query, id).Scan(&b.ID, &b.SHA256, &b.ImagePath, &b.MimeType, &b.RefCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute select on image_blob %w", err)
 	}
-	nPhash3 := fmt.Sprintf("%064b", phash3)
-	if len(nPhash3) != maxLength {
-		return fmt.Errorf("phash3 %s must have a length of 64, but got %d", nPhash3, len(nPhash3))
+
+	return &b, nil
+}
+
+// UpdateValidators Updates the etag and last modified date of the card image with the id of the given image.
+func (d *PostgresCardDao) UpdateValidators(ctx context.Context, img *Image) error {
+	query := `
+		UPDATE
+			card_image 
+		SET
+			etag=$2,
+			last_modified=$3
+        WHERE
+			id = $1`
+	ct, err := d.db.Conn.Exec(ctx, query, img.ID, img.ETag, img.LastModified)
+	if err != nil {
+		return fmt.Errorf("failed to execute card image update %w", err)
+	}
+	if ra := ct.RowsAffected(); ra != 1 {
+		return fmt.Errorf("%d card image updated but expected to update card image with "+
+			"id %d", ra, img.ID.Get())
 	}
-	nPhash4 := fmt.Sprintf("%064b", phash4)
-	if len(nPhash4) != maxLength {
-		return fmt.Errorf("phash4 %s must have a length of 64, but got %d", nPhash4, len(nPhash4))
+
+	return nil
+}
+
+// DeleteImage Deletes the card image entry with the given id, the renditions of the image are deleted as well.
+func (d *PostgresCardDao) DeleteImage(ctx context.Context, id int64) error {
+	_, err := d.db.Conn.Exec(ctx, "DELETE FROM card_image WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete card image %d %w", id, err)
 	}
 
+	return nil
+}
+
+// UpdateHashes Updates all hashes of the card image with the id of the given image.
+func (d *PostgresCardDao) UpdateHashes(ctx context.Context, img *Image) error {
 	query := `
 		UPDATE
 			card_image 
@@ -568,20 +1191,122 @@ func (d *PostgresCardDao) UpdateHashes(
 			phash1=$2,
 			phash2=$3,
 			phash3=$4,
-			phash4=$5
+			phash4=$5,
+			ahash=$6,
+			dhash=$7
         WHERE
 			id = $1`
 
-	ct, err := d.db.Conn.Exec(context.TODO(), query, id,
-		nPhash1, nPhash2, nPhash3, nPhash4)
+	ct, err := d.db.Conn.Exec(ctx, query, img.ID,
+		fmt.Sprintf("%064b", img.PHash1),
+		fmt.Sprintf("%064b", img.PHash2),
+		fmt.Sprintf("%064b", img.PHash3),
+		fmt.Sprintf("%064b", img.PHash4),
+		toBitString(img.AHash),
+		toBitString(img.DHash),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to execute card image update %w", err)
 	}
 	ra := ct.RowsAffected()
 	if ra != 1 {
 		return fmt.Errorf("%d card image updated but expected to update card image with "+
-			"id %d", ra, id)
+			"id %d", ra, img.ID.Get())
 	}
 
 	return nil
 }
+
+// FindSimilarImages Returns the images of the given variant with the lowest hamming distance to the given 256 bit
+// perception hash.
+func (d *PostgresCardDao) FindSimilarImages(ctx context.Context, phash []uint64, variant string,
+	limit int) ([]ImageMatch, error) {
+	if len(phash) != 4 {
+		return nil, fmt.Errorf("expected perception hash with 4 blocks but got %d", len(phash))
+	}
+
+	query := `
+		SELECT
+			ci.id, ci.card_id, ci.face_id, c.card_set_code, c.number, COALESCE(cf.name, c.name),
+			ci.lang_lang, ci.variant, ci.image_path,
+			bit_count(ci.phash1 # $1::BIT(64)) + bit_count(ci.phash2 # $2::BIT(64)) +
+			bit_count(ci.phash3 # $3::BIT(64)) + bit_count(ci.phash4 # $4::BIT(64)) AS distance
+		FROM
+			card_image AS ci
+		JOIN
+			card AS c ON c.id = ci.card_id
+		LEFT JOIN
+			card_face AS cf ON cf.id = ci.face_id
+		WHERE
+			ci.variant = $5 AND ci.phash1 IS NOT NULL AND ci.phash2 IS NOT NULL AND
+			ci.phash3 IS NOT NULL AND ci.phash4 IS NOT NULL
+		ORDER BY
+			distance, ci.id
+		LIMIT $6`
+	rows, err := d.db.Conn.Query(ctx, query,
+		fmt.Sprintf("%064b", phash[0]),
+		fmt.Sprintf("%064b", phash[1]),
+		fmt.Sprintf("%064b", phash[2]),
+		fmt.Sprintf("%064b", phash[3]),
+		variant, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute similarity select on card_image %w", err)
+	}
+	defer rows.Close()
+
+	var result []ImageMatch
+	for rows.Next() {
+		var m ImageMatch
+		if err := rows.Scan(&m.ImageID, &m.CardID, &m.FaceID, &m.SetCode, &m.Number, &m.Name, &m.Lang,
+			&m.Variant, &m.ImagePath, &m.Distance); err != nil {
+			return nil, fmt.Errorf("failed to scan similar card image %w", err)
+		}
+		result = append(result, m)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read similar card image result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// toBitString Returns the hash as bit string e.g. 0101 or nil if the hash is empty.
+func toBitString(hash []uint64) *string {
+	if len(hash) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	for _, h := range hash {
+		_, _ = fmt.Fprintf(&sb, "%064b", h)
+	}
+	bits := sb.String()
+
+	return &bits
+}
+
+// firstBlock Returns the first 64 bits of a bit column, 0 if the column is null.
+func firstBlock(b pgtype.Bits) uint64 {
+	if hash := fromBits(b); len(hash) > 0 {
+		return hash[0]
+	}
+
+	return 0
+}
+
+// fromBits Returns the hash of a bit varying column in 64 bit blocks, nil if the column is null.
+func fromBits(b pgtype.Bits) []uint64 {
+	if !b.Valid || b.Len == 0 {
+		return nil
+	}
+
+	hash := make([]uint64, (b.Len+63)/64)
+	for i := range hash {
+		block := make([]byte, 8)
+		copy(block, b.Bytes[min(i*8, len(b.Bytes)):])
+		hash[i] = binary.BigEndian.Uint64(block)
+	}
+
+	return hash
+}
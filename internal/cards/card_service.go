@@ -8,6 +8,8 @@ import (
 )
 
 type Service[T any] interface {
+	// Import Creates or updates the entry. The translations of cards and sets are replaced unless KeepTranslations is
+	// set, an entry without translations removes the existing ones.
 	Import(data T) error
 	Count() (int, error)
 }
@@ -65,6 +67,9 @@ func (s *cardService) Import(card *Card) error {
 			return err
 		}
 
+		if f.KeepTranslations {
+			continue
+		}
 		err := s.dao.withTransaction(func(txDao *PostgresCardDao) error {
 			return mergeFaceTranslations(txDao, f.Translations, faceID, isNewCard)
 		})
@@ -64,6 +64,31 @@ func TestBuildFilenameFailsOnUnknownContentType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported mime type")
 }
 
+func TestImageHashes(t *testing.T) {
+	img := cards.Image{
+		PHash1: 1,
+		PHash2: 2,
+		PHash3: 3,
+		PHash4: 4,
+		AHash:  []uint64{5},
+		DHash:  []uint64{6, 7, 8, 9},
+	}
+
+	assert.Equal(t, []uint64{1, 2, 3, 4}, img.PerceptionHash().GetHash())
+	assert.Equal(t, 256, img.PerceptionHash().Bits())
+	assert.Equal(t, []uint64{5}, img.AverageHash().GetHash())
+	assert.Equal(t, 64, img.AverageHash().Bits())
+	assert.Equal(t, []uint64{6, 7, 8, 9}, img.DifferenceHash().GetHash())
+	assert.Equal(t, 256, img.DifferenceHash().Bits())
+}
+
+func TestImageHashesNotComputed(t *testing.T) {
+	img := cards.Image{}
+
+	assert.Nil(t, img.AverageHash())
+	assert.Nil(t, img.DifferenceHash())
+}
+
 func TestFaceDiffWithDifferentColors(t *testing.T) {
 	firstFace := cards.Face{Colors: cards.NewColors([]string{"W", "B"})}
 	secFace := cards.Face{Colors: cards.NewColors([]string{"W"})}
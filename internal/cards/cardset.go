@@ -14,6 +14,13 @@ type CardSet struct {
 	Block        CardBlock
 	Type         string
 	Translations []SetTranslation
+	// KeepTranslations true if the source does not provide translations, the existing translations are kept and
+	// Translations is ignored. Otherwise, the existing translations are replaced with Translations.
+	KeepTranslations bool
+	// nil if the source does not provide sealed products, existing ones are kept in that case
+	SealedProducts []SealedProduct
+	// nil if the source does not provide booster configurations, existing ones are kept in that case
+	Boosters []Booster
 }
 
 func (s *CardSet) isValid() error {
@@ -25,6 +32,17 @@ func (s *CardSet) isValid() error {
 		return fmt.Errorf("field 'type' must not be empty in set %s", s.Code)
 	}
 
+	for i := range s.SealedProducts {
+		if err := s.SealedProducts[i].isValid(); err != nil {
+			return fmt.Errorf("invalid sealed product in set %s, %w", s.Code, err)
+		}
+	}
+	for i := range s.Boosters {
+		if err := s.Boosters[i].isValid(); err != nil {
+			return fmt.Errorf("invalid booster in set %s, %w", s.Code, err)
+		}
+	}
+
 	return nil
 }
 
@@ -19,6 +19,14 @@ func NewSetDao(db *postgres.DBConnection) *PostgresSetDao {
 	}
 }
 
+func (d *PostgresSetDao) withTransaction(f func(txDao *PostgresSetDao) error) error {
+	ctx := context.TODO()
+	// create a new dao instance with a transactional connection
+	return d.db.WithTransaction(ctx, func(txConn *postgres.DBConnection) error {
+		return f(NewSetDao(txConn))
+	})
+}
+
 func (d *PostgresSetDao) UpdateTranslation(setCode string, t *SetTranslation) error {
 	query := `
 		UPDATE
@@ -182,6 +190,46 @@ func (d *PostgresSetDao) FindCardSetByCode(code string) (*CardSet, error) {
 	return set, nil
 }
 
+// FindSetIcons Returns the stored icon path of every set by set code, the path is empty if no icon is stored.
+func (d *PostgresSetDao) FindSetIcons(ctx context.Context) (map[string]string, error) {
+	rows, err := d.db.Conn.Query(ctx, "SELECT code, COALESCE(icon_path, '') FROM card_set")
+	if err != nil {
+		return nil, fmt.Errorf("failed to select set icons %w", err)
+	}
+	defer rows.Close()
+
+	result := map[string]string{}
+	for rows.Next() {
+		var code, iconPath string
+		if err := rows.Scan(&code, &iconPath); err != nil {
+			return nil, fmt.Errorf("failed to select set icons %w", err)
+		}
+
+		result[code] = iconPath
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read set icon result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// UpdateSetIcon Sets the stored icon path of the set with the given code.
+func (d *PostgresSetDao) UpdateSetIcon(ctx context.Context, code, iconPath string) error {
+	ct, err := d.db.Conn.Exec(ctx, "UPDATE card_set SET icon_path = $1 WHERE code = $2", iconPath, code)
+	if err != nil {
+		return fmt.Errorf("failed to update icon of set %s %w", code, err)
+	}
+
+	ra := ct.RowsAffected()
+	if ra != 1 {
+		return fmt.Errorf("%d sets updated but expected to update set with code %s", ra, code)
+	}
+
+	return nil
+}
+
 func (d *PostgresSetDao) CreateBlock(block string) (*CardBlock, error) {
 	query := `
 		INSERT INTO
@@ -240,3 +288,273 @@ func (d *PostgresSetDao) Count() (int, error) {
 
 	return count, nil
 }
+
+// DeleteSealedProducts Deletes all sealed products of the given set.
+func (d *PostgresSetDao) DeleteSealedProducts(setCode string) error {
+	query := `
+		DELETE FROM
+			sealed_product
+		WHERE
+			card_set_code = $1`
+
+	_, err := d.db.Conn.Exec(context.TODO(), query, setCode)
+	if err != nil {
+		return fmt.Errorf("failed to delete sealed products of set %s %w", setCode, err)
+	}
+
+	return nil
+}
+
+// CreateSealedProduct Creates a new sealed product with a reference to the given set.
+func (d *PostgresSetDao) CreateSealedProduct(setCode string, p *SealedProduct) error {
+	query := `
+		INSERT INTO
+			sealed_product (
+				mtgjson_id, name, category, subtype, contents, card_set_code
+			)
+		VALUES (
+			$1, $2, $3, $4, $5, $6
+		)`
+
+	var contents any
+	if p.Contents != "" {
+		contents = p.Contents
+	}
+	_, err := d.db.Conn.Exec(context.TODO(), query, p.MtgjsonID, p.Name, p.Category, p.Subtype, contents, setCode)
+	if err != nil {
+		return fmt.Errorf("failed to insert sealed product %s %w", p.MtgjsonID, err)
+	}
+
+	return nil
+}
+
+// FindSealedProducts Returns all sealed products of the given set.
+func (d *PostgresSetDao) FindSealedProducts(setCode string) ([]*SealedProduct, error) {
+	query := `
+		SELECT
+			mtgjson_id, name, category, subtype, COALESCE(contents::text, '')
+		FROM
+			sealed_product
+		WHERE
+			card_set_code = $1
+		ORDER BY
+			name, mtgjson_id`
+
+	rows, err := d.db.Conn.Query(context.TODO(), query, setCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select sealed products of set %s %w", setCode, err)
+	}
+	defer rows.Close()
+
+	var result []*SealedProduct
+	for rows.Next() {
+		p := &SealedProduct{}
+		if err := rows.Scan(&p.MtgjsonID, &p.Name, &p.Category, &p.Subtype, &p.Contents); err != nil {
+			return nil, fmt.Errorf("failed to scan after sealed product select %w", err)
+		}
+		result = append(result, p)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read row after sealed product select %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// DeleteBoosters Deletes all booster configurations of the given set including variants and sheets.
+func (d *PostgresSetDao) DeleteBoosters(setCode string) error {
+	query := `
+		DELETE FROM
+			booster
+		WHERE
+			card_set_code = $1`
+
+	_, err := d.db.Conn.Exec(context.TODO(), query, setCode)
+	if err != nil {
+		return fmt.Errorf("failed to delete boosters of set %s %w", setCode, err)
+	}
+
+	return nil
+}
+
+// CreateBooster Creates a new booster configuration including variants and sheets for the given set.
+func (d *PostgresSetDao) CreateBooster(setCode string, b *Booster) error {
+	ctx := context.TODO()
+
+	query := `
+		INSERT INTO
+			booster (
+				name, card_set_code
+			)
+		VALUES (
+			$1, $2
+		)
+		RETURNING
+			id`
+
+	var boosterID int64
+	if err := d.db.Conn.QueryRow(ctx, query, b.Name, setCode).Scan(&boosterID); err != nil {
+		return fmt.Errorf("failed to insert booster %s of set %s %w", b.Name, setCode, err)
+	}
+
+	variantQuery := `
+		INSERT INTO
+			booster_variant (
+				weight, contents, booster_id
+			)
+		VALUES (
+			$1, $2, $3
+		)`
+	for _, v := range b.Variants {
+		if _, err := d.db.Conn.Exec(ctx, variantQuery, v.Weight, v.Contents, boosterID); err != nil {
+			return fmt.Errorf("failed to insert variant of booster %s and set %s %w", b.Name, setCode, err)
+		}
+	}
+
+	sheetQuery := `
+		INSERT INTO
+			booster_sheet (
+				name, foil, fixed, allow_duplicates, balance_colors, booster_id
+			)
+		VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		RETURNING
+			id`
+	cardQuery := `
+		INSERT INTO
+			booster_sheet_card (
+				mtgjson_id, weight, sheet_id
+			)
+		VALUES (
+			$1, $2, $3
+		)`
+	for _, s := range b.Sheets {
+		var sheetID int64
+		err := d.db.Conn.QueryRow(ctx, sheetQuery, s.Name, s.Foil, s.Fixed, s.AllowDuplicates, s.BalanceColors, boosterID).
+			Scan(&sheetID)
+		if err != nil {
+			return fmt.Errorf("failed to insert sheet %s of booster %s and set %s %w", s.Name, b.Name, setCode, err)
+		}
+
+		for _, c := range s.Cards {
+			if _, err := d.db.Conn.Exec(ctx, cardQuery, c.MtgjsonID, c.Weight, sheetID); err != nil {
+				return fmt.Errorf("failed to insert card %s of sheet %s %w", c.MtgjsonID, s.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindBooster Returns the booster configuration with the given name of the given set.
+func (d *PostgresSetDao) FindBooster(setCode string, name string) (*Booster, error) {
+	ctx := context.TODO()
+
+	query := `
+		SELECT
+			id, name
+		FROM
+			booster
+		WHERE
+			card_set_code = $1 AND name = $2`
+
+	var boosterID int64
+	b := &Booster{}
+	if err := d.db.Conn.QueryRow(ctx, query, setCode, name).Scan(&boosterID, &b.Name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to select booster %s of set %s %w", name, setCode, err)
+	}
+
+	variants, err := d.findBoosterVariants(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	b.Variants = variants
+
+	sheets, err := d.findBoosterSheets(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	b.Sheets = sheets
+
+	return b, nil
+}
+
+func (d *PostgresSetDao) findBoosterVariants(ctx context.Context, boosterID int64) ([]BoosterVariant, error) {
+	query := `
+		SELECT
+			weight, contents
+		FROM
+			booster_variant
+		WHERE
+			booster_id = $1
+		ORDER BY
+			id`
+
+	rows, err := d.db.Conn.Query(ctx, query, boosterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select booster variants %w", err)
+	}
+	defer rows.Close()
+
+	var result []BoosterVariant
+	for rows.Next() {
+		var v BoosterVariant
+		if err := rows.Scan(&v.Weight, &v.Contents); err != nil {
+			return nil, fmt.Errorf("failed to scan after booster variant select %w", err)
+		}
+		result = append(result, v)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read row after booster variant select %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+func (d *PostgresSetDao) findBoosterSheets(ctx context.Context, boosterID int64) ([]BoosterSheet, error) {
+	query := `
+		SELECT
+			s.name, s.foil, s.fixed, s.allow_duplicates, s.balance_colors, c.mtgjson_id, c.weight
+		FROM
+			booster_sheet AS s
+		JOIN
+			booster_sheet_card AS c
+		ON
+			s.id = c.sheet_id
+		WHERE
+			s.booster_id = $1
+		ORDER BY
+			s.name, c.mtgjson_id`
+
+	rows, err := d.db.Conn.Query(ctx, query, boosterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select booster sheets %w", err)
+	}
+	defer rows.Close()
+
+	var result []BoosterSheet
+	for rows.Next() {
+		var s BoosterSheet
+		var c SheetCard
+		if err := rows.Scan(&s.Name, &s.Foil, &s.Fixed, &s.AllowDuplicates, &s.BalanceColors, &c.MtgjsonID,
+			&c.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan after booster sheet select %w", err)
+		}
+
+		if len(result) == 0 || result[len(result)-1].Name != s.Name {
+			result = append(result, s)
+		}
+		last := &result[len(result)-1]
+		last.Cards = append(last.Cards, c)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read row after booster sheet select %w", rows.Err())
+	}
+
+	return result, nil
+}
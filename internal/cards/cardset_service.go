@@ -71,7 +71,47 @@ func (s *setService) Import(set *CardSet) error {
 		}
 	}
 
-	return mergeSetTranslations(s.dao, set.Translations, set.Code, existingSet == nil)
+	if !set.KeepTranslations {
+		if err := mergeSetTranslations(s.dao, set.Translations, set.Code, existingSet == nil); err != nil {
+			return err
+		}
+	}
+
+	return replaceSealedData(s.dao, set)
+}
+
+// replaceSealedData Replaces the sealed products and booster configurations of the set.
+// Nothing is replaced if the set does not provide the data.
+func replaceSealedData(dao *PostgresSetDao, set *CardSet) error {
+	if set.SealedProducts == nil && set.Boosters == nil {
+		return nil
+	}
+
+	return dao.withTransaction(func(txDao *PostgresSetDao) error {
+		if set.SealedProducts != nil {
+			if err := txDao.DeleteSealedProducts(set.Code); err != nil {
+				return err
+			}
+			for i := range set.SealedProducts {
+				if err := txDao.CreateSealedProduct(set.Code, &set.SealedProducts[i]); err != nil {
+					return err
+				}
+			}
+		}
+
+		if set.Boosters != nil {
+			if err := txDao.DeleteBoosters(set.Code); err != nil {
+				return err
+			}
+			for i := range set.Boosters {
+				if err := txDao.CreateBooster(set.Code, &set.Boosters[i]); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
 }
 
 func mergeSetTranslations(dao *PostgresSetDao, tt []SetTranslation, setCode string, isNew bool) error {
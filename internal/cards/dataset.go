@@ -5,8 +5,10 @@ import (
 )
 
 type Report struct {
-	CardCount int
-	SetCount  int
+	CardCount  int
+	SetCount   int
+	PriceCount int
+	DeckCount  int
 }
 
 type Dataset interface {
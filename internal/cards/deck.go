@@ -0,0 +1,65 @@
+package cards
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DeckBoardMain      = "MAIN"
+	DeckBoardSide      = "SIDE"
+	DeckBoardCommander = "COMMANDER"
+)
+
+// Deck A preconstructed deck. A deck is identified by its set code and name.
+type Deck struct {
+	ID          PrimaryID
+	CardSetCode string
+	Name        string
+	Type        string // e.g. Commander Deck, Theme Deck
+	Released    time.Time
+	Entries     []DeckEntry
+}
+
+func (d *Deck) isValid() error {
+	if d.CardSetCode == "" {
+		return fmt.Errorf("field 'cardSetCode' must not be empty")
+	}
+	if d.Name == "" {
+		return fmt.Errorf("field 'name' must not be empty in deck of set %s", d.CardSetCode)
+	}
+
+	for i, e := range d.Entries {
+		if err := e.isValid(); err != nil {
+			return fmt.Errorf("entry[%d] of deck %s is invalid, %w", i, d.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// DeckEntry A card of a deck. The card is referenced by the MTGJSON uuid or by the set code and number.
+type DeckEntry struct {
+	Board       string // ENUM
+	Count       int
+	Foil        bool
+	MtgjsonID   string
+	CardSetCode string
+	Number      string
+}
+
+func (e DeckEntry) isValid() error {
+	switch e.Board {
+	case DeckBoardMain, DeckBoardSide, DeckBoardCommander:
+	default:
+		return fmt.Errorf("unsupported board %s", e.Board)
+	}
+	if e.Count <= 0 {
+		return fmt.Errorf("field 'count' must be greater than 0")
+	}
+	if e.MtgjsonID == "" && (e.CardSetCode == "" || e.Number == "") {
+		return fmt.Errorf("either field 'mtgjsonId' or 'cardSetCode' and 'number' must not be empty")
+	}
+
+	return nil
+}
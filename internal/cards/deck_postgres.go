@@ -0,0 +1,214 @@
+package cards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+)
+
+type PostgresDeckDao struct {
+	db *postgres.DBConnection
+}
+
+func NewDeckDao(db *postgres.DBConnection) *PostgresDeckDao {
+	return &PostgresDeckDao{
+		db: db,
+	}
+}
+
+func (d *PostgresDeckDao) withTransaction(f func(txDao *PostgresDeckDao) error) error {
+	ctx := context.TODO()
+	// create a new dao instance with a transactional connection
+	return d.db.WithTransaction(ctx, func(txConn *postgres.DBConnection) error {
+		return f(NewDeckDao(txConn))
+	})
+}
+
+// FindDeck Returns the deck with the given name of the given set, without entries.
+func (d *PostgresDeckDao) FindDeck(setCode string, name string) (*Deck, error) {
+	query := `
+		SELECT
+			id, card_set_code, name, COALESCE(deck_type, ''), released
+		FROM
+			deck
+		WHERE
+			card_set_code = $1 AND name = $2`
+
+	var deck Deck
+	var released *time.Time
+	err := d.db.Conn.QueryRow(context.TODO(), query, setCode, name).
+		Scan(&deck.ID, &deck.CardSetCode, &deck.Name, &deck.Type, &released)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to select deck %s of set %s %w", name, setCode, err)
+	}
+	if released != nil {
+		deck.Released = *released
+	}
+
+	return &deck, nil
+}
+
+// CreateDeck Creates a new deck without entries. The ID of the given deck will be set.
+func (d *PostgresDeckDao) CreateDeck(deck *Deck) error {
+	query := `
+		INSERT INTO
+			deck (
+				card_set_code, name, deck_type, released
+			)
+		VALUES (
+			$1, $2, NULLIF($3, ''), $4
+		)
+		RETURNING
+			id`
+
+	var id int64
+	err := d.db.Conn.QueryRow(context.TODO(), query, deck.CardSetCode, deck.Name, deck.Type, toNullDate(deck.Released)).
+		Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to insert deck %s of set %s %w", deck.Name, deck.CardSetCode, err)
+	}
+	deck.ID = NewPrimaryID(id)
+
+	return nil
+}
+
+// UpdateDeck Updates the type and release date of the given deck.
+func (d *PostgresDeckDao) UpdateDeck(deck *Deck) error {
+	query := `
+		UPDATE
+			deck
+		SET
+			deck_type = NULLIF($1, ''), released = $2
+		WHERE
+			id = $3`
+
+	_, err := d.db.Conn.Exec(context.TODO(), query, deck.Type, toNullDate(deck.Released), deck.ID.Get())
+	if err != nil {
+		return fmt.Errorf("failed to update deck %s of set %s %w", deck.Name, deck.CardSetCode, err)
+	}
+
+	return nil
+}
+
+// FindCardIDByNumber Finds the ID of the card with the given set code and number.
+func (d *PostgresDeckDao) FindCardIDByNumber(setCode string, number string) (int64, error) {
+	query := `
+		SELECT
+			id
+		FROM
+			card
+		WHERE
+			card_set_code = $1 AND number = $2`
+
+	var cardID int64
+	err := d.db.Conn.QueryRow(context.TODO(), query, setCode, number).Scan(&cardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrEntryNotFound
+		}
+
+		return 0, fmt.Errorf("failed to select card id by set %s and number %s %w", setCode, number, err)
+	}
+
+	return cardID, nil
+}
+
+// DeleteEntries Removes all entries of the given deck.
+func (d *PostgresDeckDao) DeleteEntries(deckID int64) error {
+	query := `
+		DELETE FROM
+			deck_card
+		WHERE
+			deck_id = $1`
+
+	_, err := d.db.Conn.Exec(context.TODO(), query, deckID)
+	if err != nil {
+		return fmt.Errorf("failed to delete entries of deck %d %w", deckID, err)
+	}
+
+	return nil
+}
+
+// AddEntry Adds the card to the given deck. The count is summed up if the card is already part of the board.
+func (d *PostgresDeckDao) AddEntry(deckID int64, cardID int64, e DeckEntry) error {
+	query := `
+		INSERT INTO
+			deck_card (
+				deck_id, card_id, board, foil, count
+			)
+		VALUES (
+			$1, $2, $3, $4, $5
+		)
+		ON CONFLICT (deck_id, card_id, board, foil) DO UPDATE SET count = deck_card.count + EXCLUDED.count`
+
+	_, err := d.db.Conn.Exec(context.TODO(), query, deckID, cardID, e.Board, e.Foil, e.Count)
+	if err != nil {
+		return fmt.Errorf("failed to insert card %d into deck %d %w", cardID, deckID, err)
+	}
+
+	return nil
+}
+
+// FindEntries Returns all entries of the given deck, ordered by board and card number.
+func (d *PostgresDeckDao) FindEntries(deckID int64) ([]DeckEntry, error) {
+	query := `
+		SELECT
+			dc.board, dc.count, dc.foil, c.card_set_code, c.number
+		FROM
+			deck_card AS dc
+		JOIN
+			card AS c
+		ON
+			c.id = dc.card_id
+		WHERE
+			dc.deck_id = $1
+		ORDER BY
+			dc.board, c.card_set_code, c.number`
+
+	rows, err := d.db.Conn.Query(context.TODO(), query, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select entries of deck %d %w", deckID, err)
+	}
+	defer rows.Close()
+
+	var result []DeckEntry
+	for rows.Next() {
+		var e DeckEntry
+		if err := rows.Scan(&e.Board, &e.Count, &e.Foil, &e.CardSetCode, &e.Number); err != nil {
+			return nil, fmt.Errorf("failed to scan after deck entry select %w", err)
+		}
+		result = append(result, e)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read row after deck entry select %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// Count Returns the amount of all decks.
+func (d *PostgresDeckDao) Count() (int, error) {
+	row := d.db.Conn.QueryRow(context.TODO(), "SELECT count(id) FROM deck")
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute deck count %w", err)
+	}
+
+	return count, nil
+}
+
+func toNullDate(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,101 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+type deckService struct {
+	dao     *PostgresDeckDao
+	cardDao *PostgresCardDao
+}
+
+func NewDeckService(dao *PostgresDeckDao, cardDao *PostgresCardDao) Service[*Deck] {
+	return &deckService{
+		dao:     dao,
+		cardDao: cardDao,
+	}
+}
+
+// Count Counts all decks.
+func (s *deckService) Count() (int, error) {
+	return s.dao.Count()
+}
+
+// Import Creates or updates the given deck. The entries of an existing deck are replaced.
+// Entries that reference unknown cards are skipped.
+func (s *deckService) Import(deck *Deck) error {
+	if deck == nil {
+		// Skip nil deck
+		return nil
+	}
+	if err := deck.isValid(); err != nil {
+		return fmt.Errorf("deck is invalid, %w", err)
+	}
+
+	return s.dao.withTransaction(func(txDao *PostgresDeckDao) error {
+		existing, err := txDao.FindDeck(deck.CardSetCode, deck.Name)
+		if err != nil {
+			if !errors.Is(err, ErrEntryNotFound) {
+				return err
+			}
+
+			if e := log.Trace(); e.Enabled() {
+				e.Msgf("Create deck %s of set %s", deck.Name, deck.CardSetCode)
+			}
+			if err := txDao.CreateDeck(deck); err != nil {
+				return err
+			}
+		}
+
+		if existing != nil {
+			deck.ID = existing.ID
+			if err := txDao.UpdateDeck(deck); err != nil {
+				return err
+			}
+			if err := txDao.DeleteEntries(deck.ID.Get()); err != nil {
+				return err
+			}
+		}
+
+		for _, e := range deck.Entries {
+			cardID, err := s.findCard(txDao, e)
+			if err != nil {
+				if errors.Is(err, ErrEntryNotFound) {
+					log.Warn().Msgf("Skip unknown card %s (%s-%s) of deck %s", e.MtgjsonID, e.CardSetCode, e.Number,
+						deck.Name)
+
+					continue
+				}
+
+				return err
+			}
+
+			if err := txDao.AddEntry(deck.ID.Get(), cardID, e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// findCard Finds the card by the MTGJSON uuid and falls back to the set code and number.
+func (s *deckService) findCard(dao *PostgresDeckDao, e DeckEntry) (int64, error) {
+	if e.MtgjsonID != "" {
+		c, err := s.cardDao.FindCardByMtgjsonID(e.MtgjsonID)
+		if err == nil {
+			return c.ID.Get(), nil
+		}
+		if !errors.Is(err, ErrEntryNotFound) {
+			return 0, err
+		}
+	}
+	if e.CardSetCode == "" || e.Number == "" {
+		return 0, ErrEntryNotFound
+	}
+
+	return dao.FindCardIDByNumber(e.CardSetCode, e.Number)
+}
@@ -0,0 +1,19 @@
+package cards
+
+// SimilarPair similarPair with exported fields for the tests of the cards_test package.
+type SimilarPair struct {
+	First    int
+	Second   int
+	Distance int
+}
+
+func SimilarPairs(hashes [][4]uint64, maxDistance int) []SimilarPair {
+	var result []SimilarPair
+	for _, p := range similarPairs(hashes, maxDistance) {
+		result = append(result, SimilarPair{First: p.first, Second: p.second, Distance: p.distance})
+	}
+
+	return result
+}
+
+var ParsePlaceholders = parsePlaceholders
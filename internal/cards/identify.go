@@ -0,0 +1,57 @@
+package cards
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ImageMatch A stored card image that is similar to the searched image.
+type ImageMatch struct {
+	ImageID   PrimaryID
+	CardID    PrimaryID
+	FaceID    PrimaryID
+	SetCode   string
+	Number    string
+	Name      string // name of the face
+	Lang      string
+	Variant   string
+	ImagePath string
+	Distance  int // hamming distance of the perception hashes, 0 means identical
+}
+
+// Identifier Finds the cards shown on an image e.g. a photo of a card.
+type Identifier interface {
+	Identify(ctx context.Context, r io.Reader, mimeType string, variant string, limit int) ([]ImageMatch, error)
+}
+
+type identifier struct {
+	cardDao *PostgresCardDao
+}
+
+func NewIdentifier(cardDao *PostgresCardDao) Identifier {
+	return &identifier{
+		cardDao: cardDao,
+	}
+}
+
+// Identify Returns the stored card images of the given variant with the closest perception hash, ordered by
+// distance. The same perception hash as for the imported images is used.
+func (i *identifier) Identify(ctx context.Context, r io.Reader, mimeType string, variant string,
+	limit int) ([]ImageMatch, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than zero but was %d", limit)
+	}
+
+	img, err := DecodeImage(r, mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %w", err)
+	}
+
+	hash, err := PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create phash %w", err)
+	}
+
+	return i.cardDao.FindSimilarImages(ctx, hash.GetHash(), variant, limit)
+}
@@ -0,0 +1,29 @@
+package cards_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifyInvalidLimit(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "images", "cardImageDe.jpg"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = cards.NewIdentifier(nil).Identify(t.Context(), f, "image/jpeg", cards.VariantNormal, 0)
+
+	assert.ErrorContains(t, err, "limit must be greater than zero")
+}
+
+func TestIdentifyInvalidImage(t *testing.T) {
+	_, err := cards.NewIdentifier(nil).Identify(t.Context(), strings.NewReader("no image"), "image/jpeg",
+		cards.VariantNormal, 1)
+
+	assert.ErrorContains(t, err, "failed to decode image")
+}
@@ -1,27 +1,74 @@
 package cards
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"image"
+	"image/gif"
 	"image/jpeg"
+	"image/png"
 	"io"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/corona10/goimagehash"
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
 	"github.com/konstantinfoerster/card-importer-go/internal/storage"
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
 )
 
 var ErrImageNotFound = fmt.Errorf("image not found")
 var ErrImageBroken = fmt.Errorf("image broken")
 var ErrCardNotFound = fmt.Errorf("card not found")
+var ErrImageNotModified = fmt.Errorf("image not modified")
+
+// MismatchError Returned if the source returns a card that doesn't match the requested language, set or number e.g.
+// another printing. The image of the requested card is treated as not found.
+type MismatchError struct {
+	Mismatch string // e.g. lang de != en
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("returned card doesn't match the requested card, %s", e.Mismatch)
+}
+
+func (e *MismatchError) Is(target error) bool {
+	return target == ErrImageNotFound
+}
+
+// Image variants as provided by Scryfall.
+const (
+	VariantSmall      = "small"
+	VariantNormal     = "normal"
+	VariantLarge      = "large"
+	VariantPng        = "png"
+	VariantArtCrop    = "art_crop"
+	VariantBorderCrop = "border_crop"
+)
+
+func GetSupportedVariants() []string {
+	return []string{VariantSmall, VariantNormal, VariantLarge, VariantPng, VariantArtCrop, VariantBorderCrop}
+}
 
 type ImageResult struct {
 	File     io.ReadCloser
 	MimeType web.MimeType
+	Lang     string // language of the image, the requested language if not set
+	// ETag and LastModified validators of the downloaded image, empty if the source doesn't provide them.
+	ETag         string
+	LastModified string
+	// Verified true if the source confirmed the language, set and number of the image.
+	Verified bool
+	// Mismatch why the image of the requested language was rejected before the fallback language was requested.
+	Mismatch string
 }
 
 func NewFilter(setCode, name, number, lang string) (Filter, error) {
@@ -48,6 +95,7 @@ func NewFilter(setCode, name, number, lang string) (Filter, error) {
 		Name:    name,
 		Number:  number,
 		Lang:    lang,
+		Variant: VariantNormal,
 	}, nil
 }
 
@@ -56,86 +104,235 @@ type Filter struct {
 	Name    string
 	Number  string
 	Lang    string
+	Variant string
 }
 
 type ImageDownloader interface {
 	GetImage(ctx context.Context, f Filter) (*ImageResult, error)
 }
 
+// Validators Identify the downloaded version of an image, empty values are unknown.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// ConditionalImageDownloader A downloader that only returns the image if it differs from the version with the given
+// validators, ErrImageNotModified is returned otherwise.
+type ConditionalImageDownloader interface {
+	ImageDownloader
+	GetImageIfModified(ctx context.Context, f Filter, v Validators) (*ImageResult, error)
+}
+
 type ImageReport struct {
 	TotalCards int
 	Imported   int
 	Missing    int
 	Skipped    int
+	Deferred   int // missing images that are not requested again until their retry time is reached
+}
+
+// InterruptedError Returned if the import is cancelled, all cards up to the resume id are processed.
+type InterruptedError struct {
+	ResumeAfterID int64
+	Err           error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("image import interrupted, resume after card id %d, %v", e.ResumeAfterID, e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+func (r *ImageReport) add(other ImageReport) {
+	r.Imported += other.Imported
+	r.Missing += other.Missing
+	r.Skipped += other.Skipped
+	r.Deferred += other.Deferred
+}
+
+// resumeCursor Tracks the highest card id up to which all started cards are finished. The cards must be started in
+// ascending id order.
+type resumeCursor struct {
+	lastID   int64
+	started  []int64
+	finished map[int64]bool
+}
+
+func newResumeCursor(afterID int64) *resumeCursor {
+	return &resumeCursor{lastID: afterID, finished: map[int64]bool{}}
+}
+
+func (c *resumeCursor) start(id int64) {
+	c.started = append(c.started, id)
+}
+
+func (c *resumeCursor) finish(id int64) {
+	c.finished[id] = true
+	for len(c.started) > 0 && c.finished[c.started[0]] {
+		c.lastID = c.started[0]
+		delete(c.finished, c.started[0])
+		c.started = c.started[1:]
+	}
 }
 
 type PageConfig struct {
-	Page int // starts with 1
-	Size int
+	AfterID int64 // only cards with a greater id are imported, 0 imports all cards
+	Size    int   // amount of cards loaded at once
+}
+
+// ImageFilter Limits the image import to the matching cards and languages, empty fields match all.
+type ImageFilter struct {
+	CardFilter
+	Langs []string
+}
+
+// LangsOrDefault Returns the languages of the filter or all supported languages if not set.
+func (f ImageFilter) LangsOrDefault() []string {
+	if len(f.Langs) == 0 {
+		return GetSupportedLanguages()
+	}
+
+	return f.Langs
 }
 
 type Images interface {
-	Import(PageConfig) (ImageReport, error)
+	// Import Imports the images of all matching cards. If the context is cancelled, the running downloads are
+	// aborted and the report collected so far is returned together with an InterruptedError.
+	Import(context.Context, PageConfig, ImageFilter) (ImageReport, error)
+	// UpgradeFallbacks Replaces the fallback images once the image of the actual language exists.
+	UpgradeFallbacks(context.Context) (FallbackReport, error)
+	// Refresh Replaces the matching images that have changed since they were downloaded.
+	Refresh(context.Context, RefreshFilter) (RefreshReport, error)
 }
 
 type images struct {
 	cardDao    *PostgresCardDao
 	storer     storage.Storer
 	downloader ImageDownloader
+	cfg        config.Images
 }
 
-func NewImageImporter(cardDao *PostgresCardDao, storer storage.Storer, downloader ImageDownloader) Images {
+// NewImageImporter Creates an importer that imports the images of multiple cards in parallel, the amount of
+// parallel imports is defined by the configured workers.
+func NewImageImporter(cardDao *PostgresCardDao, storer storage.Storer, downloader ImageDownloader,
+	cfg config.Images) Images {
 	return &images{
 		cardDao:    cardDao,
 		storer:     storer,
 		downloader: downloader,
+		cfg:        cfg,
 	}
 }
 
-func (i *images) Import(pageConfig PageConfig) (ImageReport, error) {
-	ctx := context.Background()
+func (i *images) Import(parentCtx context.Context, pageConfig PageConfig, filter ImageFilter) (ImageReport,
+	error) {
+	langs := filter.LangsOrDefault()
+	for _, l := range langs {
+		if !slices.Contains(GetSupportedLanguages(), l) {
+			return ImageReport{}, fmt.Errorf("unsupported language %s, supported are %v", l, GetSupportedLanguages())
+		}
+	}
+
+	variants := i.cfg.VariantsOrDefault()
+	for _, v := range variants {
+		if !slices.Contains(GetSupportedVariants(), v) {
+			return ImageReport{}, fmt.Errorf("unsupported image variant %s, supported are %v", v,
+				GetSupportedVariants())
+		}
+	}
+
+	if err := validateHashSizes(i.cfg); err != nil {
+		return ImageReport{}, err
+	}
+
+	errg, ctx := errgroup.WithContext(parentCtx)
+	errg.SetLimit(i.cfg.WorkersOrDefault())
 
-	page := max(pageConfig.Page-1, 0)
-	pageSize := max(pageConfig.Size, 0)
+	var mu sync.Mutex
+	batchSize := max(pageConfig.Size, 1)
 	report := ImageReport{}
+	cursor := newResumeCursor(pageConfig.AfterID)
 
-	cardCount, err := i.cardDao.Count()
+	cardCount, err := i.cardDao.CountMatching(filter.CardFilter)
 	if err != nil {
 		return ImageReport{}, fmt.Errorf("failed to get card count %w", err)
 	}
 
 	report.TotalCards = cardCount
 
-	maxPages := cardCount / pageSize
-	for {
-		page++
-		cards, err := i.cardDao.Paged(page, pageSize)
-		if err != nil {
-			return ImageReport{}, fmt.Errorf("failed to get card list for page %d and size %d. %w", page, pageSize, err)
-		}
-		if len(cards) == 0 {
-			break
+	processed := 0
+	for r := range i.cardDao.StreamCards(ctx, pageConfig.AfterID, batchSize, filter.CardFilter) {
+		if r.Err != nil {
+			// wait for running imports before returning
+			_ = errg.Wait()
+
+			return ImageReport{}, fmt.Errorf("failed to get card list after id %d with size %d. %w",
+				pageConfig.AfterID, batchSize, r.Err)
 		}
 
-		log.Info().Msgf("Processing page %d/%d with %d cards", page, maxPages, len(cards))
-		for _, c := range cards {
-			for _, lang := range GetSupportedLanguages() {
-				if err = i.importCard(ctx, c, lang, &report); err != nil {
-					return ImageReport{}, err
-				}
+		c := *r.Result
+		mu.Lock()
+		cursor.start(c.ID.Get())
+		mu.Unlock()
+		errg.Go(func() error {
+			cardReport := ImageReport{}
+			err := i.importCardLangs(ctx, c, langs, variants, &cardReport)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.add(cardReport)
+			if err != nil {
+				return err
 			}
+			cursor.finish(c.ID.Get())
+
+			return nil
+		})
+
+		processed++
+		if processed%batchSize == 0 {
+			log.Info().Msgf("Started import of card %d/%d with id %d", processed, cardCount, c.ID.Get())
 		}
 	}
 
+	err = errg.Wait()
+	if parentCtx.Err() != nil {
+		return report, &InterruptedError{ResumeAfterID: cursor.lastID, Err: parentCtx.Err()}
+	}
+	if err != nil {
+		return ImageReport{}, err
+	}
+
 	return report, nil
 }
 
-func (i *images) importCard(ctx context.Context, c Card, lang string, report *ImageReport) error {
+func (i *images) importCardLangs(ctx context.Context, c Card, langs, variants []string, report *ImageReport) error {
+	for _, lang := range langs {
+		for _, variant := range variants {
+			if err := i.importCard(ctx, c, lang, variant, report); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// importCard Imports the images of all faces in the given language and variant. A cancelled context stops the
+// import before the next face, a face whose image is already stored is still added.
+func (i *images) importCard(ctx context.Context, c Card, lang, variant string, report *ImageReport) error {
 	for _, f := range c.Faces {
-		imgExists, err := i.cardDao.IsImagePresent(ctx, f.ID.Get(), lang)
+		if ctx.Err() != nil {
+			return fmt.Errorf("image import of card face %d aborted %w", f.ID.Get(), ctx.Err())
+		}
+
+		imgExists, err := i.cardDao.IsImagePresent(ctx, f.ID.Get(), lang, variant)
 		if err != nil {
 			return fmt.Errorf("failed to check if card image already exists for card face with set %s, "+
-				"name %s, number %s and language %s, %w", c.CardSetCode, f.Name, c.Number, lang, err)
+				"name %s, number %s, language %s and variant %s, %w", c.CardSetCode, f.Name, c.Number, lang, variant, err)
 		}
 		if imgExists {
 			report.Skipped++
@@ -143,46 +340,64 @@ func (i *images) importCard(ctx context.Context, c Card, lang string, report *Im
 			continue
 		}
 
+		miss, err := i.cardDao.FindImageMiss(ctx, f.ID.Get(), lang, variant)
+		if err != nil && !errors.Is(err, ErrEntryNotFound) {
+			return fmt.Errorf("failed to find image miss of card face %d, %w", f.ID.Get(), err)
+		}
+		now := time.Now()
+		if miss != nil && now.Before(miss.NextRetry) {
+			report.Deferred++
+
+			continue
+		}
+
 		filter := Filter{
 			SetCode: c.CardSetCode,
 			Name:    f.Name,
 			Number:  c.Number,
 			Lang:    lang,
+			Variant: variant,
 		}
 		cardImg := Image{
-			Lang:   lang,
-			CardID: c.ID,
-			FaceID: f.ID,
+			Lang:    lang,
+			Variant: variant,
+			CardID:  c.ID,
+			FaceID:  f.ID,
 		}
 		if err := i.addImageData(ctx, &cardImg, filter); err != nil {
-			switch {
-			case errors.Is(err, ErrCardNotFound):
-				log.Warn().Any("filter", filter).Int64("cardID", c.ID.Get()).Int64("faceID", f.ID.Get()).Msg("card not found")
-
-				report.Missing++
+			if ctx.Err() != nil {
+				// failed due to the cancellation, not because the image is missing
+				return fmt.Errorf("image import of card face %d aborted %w", f.ID.Get(), errors.Join(err, ctx.Err()))
+			}
 
-				continue
-			case errors.Is(err, ErrImageNotFound):
-				log.Warn().Any("filter", filter).Int64("cardID", c.ID.Get()).Int64("faceID", f.ID.Get()).Msg("card image not found")
+			reason := missReason(err)
+			if reason == "" {
+				return err
+			}
 
-				report.Missing++
+			log.Warn().Any("filter", filter).Int64("cardID", c.ID.Get()).Int64("faceID", f.ID.Get()).
+				Msgf("card image missing, %s", reason)
+			if err := i.addMiss(ctx, cardImg, reason, miss, now); err != nil {
+				return err
+			}
 
-				continue
-			case errors.Is(err, ErrImageBroken):
-				log.Warn().Any("filter", filter).Int64("cardID", c.ID.Get()).Int64("faceID", f.ID.Get()).Msg("card image broken")
+			report.Missing++
 
-				report.Missing++
+			continue
+		}
 
-				continue
-			default:
+		// the image is stored, finish the face even if the import is cancelled meanwhile
+		writeCtx := context.WithoutCancel(ctx)
+		if err = i.cardDao.AddImage(writeCtx, &cardImg); err != nil {
+			return fmt.Errorf("failed to add image entry with filter %#v, %w", filter,
+				errors.Join(err, releaseBlob(writeCtx, i.cardDao, i.storer, &cardImg)))
+		}
+		if miss != nil {
+			if err := i.cardDao.DeleteImageMiss(writeCtx, miss.ID.Get()); err != nil {
 				return err
 			}
 		}
 
-		if err = i.cardDao.AddImage(ctx, &cardImg); err != nil {
-			return fmt.Errorf("failed to add image entry with filter %#v, %w", filter, err)
-		}
-
 		log.Debug().Any("filter", filter).Msgf("stored card image at %s", cardImg.ImagePath)
 
 		report.Imported++
@@ -191,39 +406,156 @@ func (i *images) importCard(ctx context.Context, c Card, lang string, report *Im
 	return nil
 }
 
+// addMiss Records the missing image, the attempts of a previous miss are continued.
+func (i *images) addMiss(ctx context.Context, cardImg Image, reason string, previous *ImageMiss, now time.Time) error {
+	attempts := 1
+	if previous != nil {
+		attempts = previous.Attempts + 1
+	}
+
+	miss := &ImageMiss{
+		CardID:      cardImg.CardID,
+		FaceID:      cardImg.FaceID,
+		Lang:        cardImg.Lang,
+		Variant:     cardImg.Variant,
+		Reason:      reason,
+		Attempts:    attempts,
+		LastAttempt: now,
+		NextRetry:   NextRetry(now, attempts, i.cfg),
+	}
+	if err := i.cardDao.SaveImageMiss(ctx, miss); err != nil {
+		return fmt.Errorf("failed to save image miss of card face %d, %w", cardImg.FaceID.Get(), err)
+	}
+
+	return nil
+}
+
 func (i *images) addImageData(ctx context.Context, cardImg *Image, filter Filter) error {
 	result, err := i.GetImageWithFallback(ctx, filter, FallbackLang)
 	if err != nil {
 		return fmt.Errorf("failed to download card image with filter %#v, %w", filter, err)
 	}
 
+	return i.storeImage(ctx, cardImg, filter, result)
+}
+
+// storeImage Stores the downloaded image, computes the hashes and creates the renditions. The image is stored under
+// the language of the filter, even if the downloaded image has a different language. With content addressing the
+// image is stored as blob and the caller owns the reference to the blob.
+func (i *images) storeImage(ctx context.Context, cardImg *Image, filter Filter, result *ImageResult) error {
+	defer aio.Close(result.File)
+
 	cardImg.MimeType = result.MimeType.Raw()
+	cardImg.SourceLang = result.Lang
+	if cardImg.SourceLang == "" {
+		cardImg.SourceLang = filter.Lang
+	}
+	cardImg.Fallback = cardImg.SourceLang != cardImg.Lang
+	cardImg.ETag = result.ETag
+	cardImg.LastModified = result.LastModified
+	cardImg.Verified = result.Verified
+	cardImg.Mismatch = result.Mismatch
+	cardImg.BlobID = PrimaryID{}
 
 	fileName, err := cardImg.BuildFilename()
 	if err != nil {
 		return fmt.Errorf("failed to build filename %w", err)
 	}
 
-	storedFile, err := i.storer.Store(result.File, filter.Lang, filter.SetCode, fileName)
+	if i.cfg.ContentAddressed {
+		return i.storeBlobImage(ctx, cardImg, filter, fileName, result.File)
+	}
+
+	storedFile, err := i.storer.Store(result.File, imagePath(filter, fileName)...)
 	if err != nil {
 		return fmt.Errorf("failed to store card with filter %#v, %w", filter, err)
 	}
 	cardImg.ImagePath = storedFile.Path
 
-	fImg, err := os.Open(storedFile.AbsolutePath)
+	if err := i.processStoredImage(cardImg, filter, fileName, storedFile.AbsolutePath); err != nil {
+		// the file is deleted, so the next attempt can store the image again
+		return errors.Join(err, i.storer.Delete(storedFile.Path))
+	}
+
+	return nil
+}
+
+// processStoredImage Processes the stored file of the image.
+func (i *images) processStoredImage(cardImg *Image, filter Filter, fileName, absolutePath string) error {
+	fImg, err := os.Open(absolutePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file %s, %w", storedFile.AbsolutePath, err)
+		return fmt.Errorf("failed to open file %s, %w", absolutePath, err)
 	}
 	defer fImg.Close()
 
-	img, err := jpeg.Decode(fImg)
+	return i.processImage(cardImg, filter, fileName, fImg)
+}
+
+// storeBlobImage Stores the image as blob, the reference to the blob is released if the image can't be processed.
+func (i *images) storeBlobImage(ctx context.Context, cardImg *Image, filter Filter, fileName string,
+	r io.Reader) error {
+	content, err := io.ReadAll(r)
 	if err != nil {
-		return fmt.Errorf("failed to decode image %s, %w", storedFile.AbsolutePath, errors.Join(err, ErrImageBroken))
+		return fmt.Errorf("failed to read card image with filter %#v, %w", filter, err)
 	}
 
-	imgWidth := 16
-	imgHeight := imgWidth
-	imgPHash, err := goimagehash.ExtPerceptionHash(img, imgWidth, imgHeight)
+	blob, err := acquireBlob(ctx, i.cardDao, i.storer, content, cardImg.MimeType)
+	if err != nil {
+		return err
+	}
+	cardImg.ImagePath = blob.ImagePath
+	cardImg.BlobID = blob.ID
+
+	if err := i.processImage(cardImg, filter, fileName, bytes.NewReader(content)); err != nil {
+		rErr := releaseBlob(context.WithoutCancel(ctx), i.cardDao, i.storer, cardImg)
+		cardImg.BlobID = PrimaryID{}
+
+		return errors.Join(err, rErr)
+	}
+
+	return nil
+}
+
+// processImage Decodes the stored image, computes the hashes and creates the renditions.
+func (i *images) processImage(cardImg *Image, filter Filter, fileName string, r io.Reader) error {
+	img, err := DecodeImage(r, cardImg.MimeType)
+	if err != nil {
+		return fmt.Errorf("failed to decode image %s, %w", cardImg.ImagePath, errors.Join(err, ErrImageBroken))
+	}
+
+	if err := computeHashes(cardImg, img, i.cfg); err != nil {
+		return err
+	}
+
+	renditions, err := createRenditions(i.storer, img, filter, fileName, i.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create renditions of %s, %w", cardImg.ImagePath, err)
+	}
+	cardImg.Renditions = renditions
+
+	return nil
+}
+
+// maxHashBits The maximum amount of bits of the average and difference hash columns.
+const maxHashBits = 4096
+
+// validateHashSizes Checks that the configured hash sizes can be stored in 64 bit blocks and fit into the hash columns.
+func validateHashSizes(cfg config.Images) error {
+	for _, size := range []int{cfg.AverageHashSizeOrDefault(), cfg.DifferenceHashSizeOrDefault()} {
+		if size%8 != 0 {
+			return fmt.Errorf("unsupported hash size %d, size must be a multiple of 8", size)
+		}
+		if size*size > maxHashBits {
+			return fmt.Errorf("unsupported hash size %d, size * size must not exceed %d bits", size, maxHashBits)
+		}
+	}
+
+	return nil
+}
+
+// computeHashes Sets the perception, average and difference hash of the image.
+func computeHashes(cardImg *Image, img image.Image, cfg config.Images) error {
+	imgPHash, err := PerceptionHash(img)
 	if err != nil {
 		return fmt.Errorf("failed to create phash from %s, %w", cardImg.ImagePath, err)
 	}
@@ -233,20 +565,85 @@ func (i *images) addImageData(ctx context.Context, cardImg *Image, filter Filter
 	cardImg.PHash3 = imgPHash.GetHash()[2]
 	cardImg.PHash4 = imgPHash.GetHash()[3]
 
+	aSize := cfg.AverageHashSizeOrDefault()
+	imgAHash, err := goimagehash.ExtAverageHash(img, aSize, aSize)
+	if err != nil {
+		return fmt.Errorf("failed to create ahash from %s, %w", cardImg.ImagePath, err)
+	}
+	cardImg.AHash = imgAHash.GetHash()
+
+	dSize := cfg.DifferenceHashSizeOrDefault()
+	imgDHash, err := goimagehash.ExtDifferenceHash(img, dSize, dSize)
+	if err != nil {
+		return fmt.Errorf("failed to create dhash from %s, %w", cardImg.ImagePath, err)
+	}
+	cardImg.DHash = imgDHash.GetHash()
+
 	return nil
 }
 
+// PerceptionHash Returns the 256 bit (16x16) extended perception hash that is stored for every card image.
+func PerceptionHash(img image.Image) (*goimagehash.ExtImageHash, error) {
+	imgWidth := 16
+	imgHeight := imgWidth
+
+	return goimagehash.ExtPerceptionHash(img, imgWidth, imgHeight)
+}
+
+// DecodeImage Decodes the image with the decoder of the given mime type. Unknown mime types are decoded with any
+// registered decoder (jpeg, png or gif).
+func DecodeImage(r io.Reader, mimeType string) (image.Image, error) {
+	switch web.NewMimeType(mimeType).Raw() {
+	case web.MimeTypeJpeg:
+		return jpeg.Decode(r)
+	case web.MimeTypePng:
+		return png.Decode(r)
+	case web.MimeTypeGif:
+		return gif.Decode(r)
+	default:
+		img, _, err := image.Decode(r)
+
+		return img, err
+	}
+}
+
+// imagePath Returns the storage path of the image. Normal images are stored directly in the set directory, all
+// other variants in a sub directory named after the variant.
+func imagePath(filter Filter, fileName string) []string {
+	if filter.Variant == "" || filter.Variant == VariantNormal {
+		return []string{filter.Lang, filter.SetCode, fileName}
+	}
+
+	return []string{filter.Lang, filter.SetCode, filter.Variant, fileName}
+}
+
+// GetImageWithFallback Returns the image of the filter language or the image of the fallback language if no image
+// of the filter language exists. The language of the result is the language of the returned image, the result
+// contains the mismatch if the image of the filter language was rejected.
 func (i *images) GetImageWithFallback(ctx context.Context, filter Filter, fallbackLang string) (*ImageResult, error) {
 	result, err := i.downloader.GetImage(ctx, filter)
 	if err != nil {
 		if errors.Is(err, ErrImageNotFound) && filter.Lang != fallbackLang {
+			var mismatch *MismatchError
+			errors.As(err, &mismatch)
+
 			// try to get image for another language
 			filter.Lang = fallbackLang
 
-			return i.downloader.GetImage(ctx, filter)
+			result, err = i.downloader.GetImage(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			if mismatch != nil {
+				result.Mismatch = mismatch.Mismatch
+			}
+		} else {
+			return nil, err
 		}
+	}
 
-		return nil, err
+	if result.Lang == "" {
+		result.Lang = filter.Lang
 	}
 
 	return result, nil
@@ -0,0 +1,101 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+)
+
+const blobsDir = "blobs"
+
+// Blob A stored file that is shared by all content addressed card images with the same content.
+type Blob struct {
+	ID        PrimaryID
+	SHA256    string
+	ImagePath string
+	MimeType  string
+	RefCount  int // amount of card images that use the blob
+}
+
+// blobPath Returns the storage path of the content with the given sha256, blobs are distributed over sub directories
+// named after the first two characters of the hash e.g. blobs/ab/ab12....jpg.
+func blobPath(sum, fileName string) []string {
+	return []string{blobsDir, sum[:2], fileName}
+}
+
+// acquireBlob Stores the content as blob or increments the reference count of the existing blob with the same
+// content. The reference is owned by the caller and must be released if the blob is not assigned to a card image.
+// The file is written while the blob row is locked, so a concurrent release can't delete it in between.
+func acquireBlob(ctx context.Context, cardDao *PostgresCardDao, storer storage.Storer, content []byte,
+	mimeType string) (*Blob, error) {
+	hash := sha256.Sum256(content)
+	sum := hex.EncodeToString(hash[:])
+	fileName, err := web.NewMimeType(mimeType).BuildFilename(sum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build blob filename %w", err)
+	}
+
+	b := &Blob{SHA256: sum, ImagePath: path.Join(blobPath(sum, fileName)...), MimeType: mimeType}
+	err = cardDao.withTransaction(func(txDao *PostgresCardDao) error {
+		if err := txDao.AcquireBlob(ctx, b); err != nil {
+			return err
+		}
+
+		// the file might be missing if it was deleted as orphan while the blob was acquired
+		if f, err := storer.Load(b.ImagePath); err == nil {
+			aio.Close(f)
+
+			return nil
+		}
+		if _, err := storer.Store(bytes.NewReader(content), strings.Split(b.ImagePath, "/")...); err != nil {
+			return fmt.Errorf("failed to store blob %s %w", b.ImagePath, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire blob %s %w", sum, err)
+	}
+
+	return b, nil
+}
+
+// releaseBlob Releases the reference to the blob of the card image and deletes the blob file once the blob has no
+// references left. Images that are not content addressed are ignored.
+func releaseBlob(ctx context.Context, cardDao *PostgresCardDao, storer storage.Storer, img *Image) error {
+	if !img.BlobID.Valid {
+		return nil
+	}
+
+	err := cardDao.withTransaction(func(txDao *PostgresCardDao) error {
+		b, err := txDao.ReleaseBlob(ctx, img.BlobID.Get())
+		if err != nil {
+			return err
+		}
+		if b.RefCount > 0 {
+			return nil
+		}
+
+		// the file is deleted while the blob row is locked, so a concurrent acquire stores the file again
+		if err := storer.Delete(b.ImagePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete blob file %s %w", b.ImagePath, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release blob %d of image %s %w", img.BlobID.Get(), img.ImagePath, err)
+	}
+
+	return nil
+}
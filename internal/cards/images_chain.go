@@ -0,0 +1,50 @@
+package cards
+
+import (
+	"context"
+	"errors"
+)
+
+type chainDownloader struct {
+	downloaders []ImageDownloader
+}
+
+// NewChainDownloader Creates a downloader that requests the image from the given downloaders in priority order.
+// The next downloader is only requested if the card or the image is not found, any other error stops the chain.
+func NewChainDownloader(downloaders ...ImageDownloader) ConditionalImageDownloader {
+	return &chainDownloader{downloaders: downloaders}
+}
+
+func (c *chainDownloader) GetImage(ctx context.Context, f Filter) (*ImageResult, error) {
+	return c.get(func(d ImageDownloader) (*ImageResult, error) {
+		return d.GetImage(ctx, f)
+	})
+}
+
+// GetImageIfModified Requests the image conditionally from downloaders that support it, all other downloaders
+// return the image unconditionally.
+func (c *chainDownloader) GetImageIfModified(ctx context.Context, f Filter, v Validators) (*ImageResult, error) {
+	return c.get(func(d ImageDownloader) (*ImageResult, error) {
+		if cd, ok := d.(ConditionalImageDownloader); ok {
+			return cd.GetImageIfModified(ctx, f, v)
+		}
+
+		return d.GetImage(ctx, f)
+	})
+}
+
+func (c *chainDownloader) get(getImage func(d ImageDownloader) (*ImageResult, error)) (*ImageResult, error) {
+	err := ErrImageNotFound
+	for _, d := range c.downloaders {
+		var result *ImageResult
+		result, err = getImage(d)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, ErrImageNotFound) && !errors.Is(err, ErrCardNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,139 @@
+package cards_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticDownloader Returns the configured content or error and counts the requests.
+type staticDownloader struct {
+	content  string
+	err      error
+	requests int
+}
+
+func (d *staticDownloader) GetImage(_ context.Context, _ cards.Filter) (*cards.ImageResult, error) {
+	d.requests++
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return &cards.ImageResult{
+		File:     io.NopCloser(strings.NewReader(d.content)),
+		MimeType: web.NewMimeType(web.MimeTypeJpeg),
+	}, nil
+}
+
+func TestChainDownloader(t *testing.T) {
+	failed := errors.New("connection refused")
+	cases := []struct {
+		name      string
+		first     *staticDownloader
+		second    *staticDownloader
+		want      string
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "first found",
+			first:     &staticDownloader{content: "first"},
+			second:    &staticDownloader{content: "second"},
+			want:      "first",
+			wantCalls: 0,
+		},
+		{
+			name:      "image not found requests next",
+			first:     &staticDownloader{err: cards.ErrImageNotFound},
+			second:    &staticDownloader{content: "second"},
+			want:      "second",
+			wantCalls: 1,
+		},
+		{
+			name:      "card not found requests next",
+			first:     &staticDownloader{err: cards.ErrCardNotFound},
+			second:    &staticDownloader{content: "second"},
+			want:      "second",
+			wantCalls: 1,
+		},
+		{
+			name:      "returns error of last downloader",
+			first:     &staticDownloader{err: cards.ErrImageNotFound},
+			second:    &staticDownloader{err: cards.ErrCardNotFound},
+			wantErr:   cards.ErrCardNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "other error stops chain",
+			first:     &staticDownloader{err: failed},
+			second:    &staticDownloader{content: "second"},
+			wantErr:   failed,
+			wantCalls: 0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := cards.NewChainDownloader(tc.first, tc.second)
+
+			result, err := chain.GetImage(t.Context(), cards.Filter{SetCode: "10E", Number: "1", Lang: "eng"})
+
+			assert.Equal(t, tc.wantCalls, tc.second.requests)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			content, err := io.ReadAll(result.File)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(content))
+		})
+	}
+}
+
+func TestChainDownloaderWithoutDownloaders(t *testing.T) {
+	_, err := cards.NewChainDownloader().GetImage(t.Context(), cards.Filter{})
+
+	require.ErrorIs(t, err, cards.ErrImageNotFound)
+}
+
+// conditionalDownloader Reports every image as not modified.
+type conditionalDownloader struct {
+	staticDownloader
+}
+
+func (d *conditionalDownloader) GetImageIfModified(_ context.Context, _ cards.Filter,
+	_ cards.Validators) (*cards.ImageResult, error) {
+	return nil, cards.ErrImageNotModified
+}
+
+func TestChainDownloaderIfModified(t *testing.T) {
+	f := cards.Filter{SetCode: "10E", Number: "1", Lang: "eng"}
+	v := cards.Validators{ETag: `"v1"`}
+
+	t.Run("conditional downloader", func(t *testing.T) {
+		chain := cards.NewChainDownloader(&staticDownloader{err: cards.ErrImageNotFound}, &conditionalDownloader{})
+
+		_, err := chain.GetImageIfModified(t.Context(), f, v)
+
+		require.ErrorIs(t, err, cards.ErrImageNotModified)
+	})
+
+	t.Run("other downloaders request unconditionally", func(t *testing.T) {
+		chain := cards.NewChainDownloader(&staticDownloader{content: "first"}, &conditionalDownloader{})
+
+		result, err := chain.GetImageIfModified(t.Context(), f, v)
+
+		require.NoError(t, err)
+		content, err := io.ReadAll(result.File)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(content))
+	})
+}
@@ -0,0 +1,56 @@
+package cards_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	src.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	encode := func(enc func(w io.Writer, img image.Image) error) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, enc(&buf, src))
+
+		return buf.Bytes()
+	}
+	jpegImg := encode(func(w io.Writer, img image.Image) error { return jpeg.Encode(w, img, nil) })
+	pngImg := encode(png.Encode)
+	gifImg := encode(func(w io.Writer, img image.Image) error { return gif.Encode(w, img, nil) })
+
+	cases := []struct {
+		name     string
+		mimeType string
+		content  []byte
+	}{
+		{name: "jpeg", mimeType: "image/jpeg", content: jpegImg},
+		{name: "png", mimeType: "image/png", content: pngImg},
+		{name: "gif", mimeType: "image/gif", content: gifImg},
+		{name: "unknown mime type detects format", mimeType: "application/octet-stream", content: pngImg},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			img, err := cards.DecodeImage(bytes.NewReader(tc.content), tc.mimeType)
+
+			require.NoError(t, err)
+			assert.Equal(t, src.Bounds(), img.Bounds())
+		})
+	}
+
+	t.Run("mime type does not match content", func(t *testing.T) {
+		_, err := cards.DecodeImage(bytes.NewReader(pngImg), "image/jpeg")
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"sync"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+type FallbackReport struct {
+	Checked  int
+	Upgraded int
+	Missing  int // still no image of the actual language
+	Requeued int // the image of the actual language is broken, the entry is deleted
+}
+
+// UpgradeFallbacks Downloads the image of the actual language of every entry that is stored with the image of the
+// fallback language. The fallback image is replaced if the image exists, the entry is not changed otherwise. If the
+// context is cancelled, the running replacements are finished and the report collected so far is returned together
+// with the error.
+func (i *images) UpgradeFallbacks(parentCtx context.Context) (FallbackReport, error) {
+	if err := validateHashSizes(i.cfg); err != nil {
+		return FallbackReport{}, err
+	}
+
+	errg, ctx := errgroup.WithContext(parentCtx)
+	errg.SetLimit(i.cfg.WorkersOrDefault())
+
+	fallbacks, err := i.cardDao.FindFallbackImages(ctx)
+	if err != nil {
+		return FallbackReport{}, fmt.Errorf("failed to get fallback images %w", err)
+	}
+
+	log.Info().Msgf("Checking %d fallback images", len(fallbacks))
+
+	var mu sync.Mutex
+	report := FallbackReport{Checked: len(fallbacks)}
+	for _, fb := range fallbacks {
+		errg.Go(func() error {
+			fbReport, err := i.upgradeFallback(ctx, fb)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Upgraded += fbReport.Upgraded
+			report.Missing += fbReport.Missing
+			report.Requeued += fbReport.Requeued
+
+			return nil
+		})
+	}
+
+	err = errg.Wait()
+	if parentCtx.Err() != nil {
+		return report, fmt.Errorf("fallback upgrade interrupted %w", parentCtx.Err())
+	}
+	if err != nil {
+		return FallbackReport{}, err
+	}
+
+	return report, nil
+}
+
+func (i *images) upgradeFallback(ctx context.Context, fb StoredImage) (FallbackReport, error) {
+	result, err := i.downloader.GetImage(ctx, fb.Filter)
+	if err != nil {
+		if errors.Is(err, ErrCardNotFound) || errors.Is(err, ErrImageNotFound) || errors.Is(err, ErrImageBroken) {
+			log.Debug().Any("filter", fb.Filter).Msg("card image still not available")
+
+			return FallbackReport{Missing: 1}, nil
+		}
+
+		return FallbackReport{}, fmt.Errorf("failed to download card image with filter %#v, %w", fb.Filter, err)
+	}
+
+	img := *fb.Image
+	if err := i.storeReplacement(context.WithoutCancel(ctx), fb.Image, &img, fb.Filter, result); err != nil {
+		if errors.Is(err, ErrImageBroken) {
+			log.Warn().Any("filter", fb.Filter).Msg("card image broken, deleted entry")
+
+			return FallbackReport{Requeued: 1}, nil
+		}
+
+		return FallbackReport{}, fmt.Errorf("failed to replace image with filter %#v, %w", fb.Filter, err)
+	}
+
+	if img.Fallback {
+		return FallbackReport{Missing: 1}, nil
+	}
+
+	log.Debug().Any("filter", fb.Filter).Msgf("replaced fallback image at %s", img.ImagePath)
+
+	return FallbackReport{Upgraded: 1}, nil
+}
+
+// storeReplacement Stores the downloaded image as replacement of the stored image and replaces the entry. The new
+// files are stored before the files of the stored image are deleted, so the entry keeps its files until it is
+// replaced. If the storage doesn't replace existing files, the files of the stored image are deleted first. The entry
+// is deleted if the new image can't be stored, because its files might already be deleted or overwritten.
+func (i *images) storeReplacement(ctx context.Context, stored *Image, img *Image, filter Filter,
+	result *ImageResult) error {
+	renditions, err := i.cardDao.FindRenditions(ctx, stored.ID.Get())
+	if err != nil {
+		return fmt.Errorf("failed to get renditions of image %s %w", stored.ImagePath, err)
+	}
+
+	content, err := io.ReadAll(result.File)
+	aio.Close(result.File)
+	if err != nil {
+		return fmt.Errorf("failed to read card image with filter %#v, %w", filter, err)
+	}
+	store := func() error {
+		r := *result
+		r.File = io.NopCloser(bytes.NewReader(content))
+
+		return i.storeImage(ctx, img, filter, &r)
+	}
+
+	err = store()
+	if errors.Is(err, fs.ErrExist) {
+		// the storage doesn't replace existing files
+		if err := deleteFiles(i.storer, imageFiles(stored, renditions)); err != nil {
+			return err
+		}
+		err = store()
+	}
+	if err == nil {
+		err = i.replaceImage(ctx, stored, img)
+	}
+	if err != nil {
+		if rErr := requeueImage(ctx, i.cardDao, i.storer, stored, true, renditions); rErr != nil {
+			return fmt.Errorf("failed to delete entry of image %s after %v, %w", stored.ImagePath, err, rErr)
+		}
+
+		return err
+	}
+
+	newFiles := imageFiles(img, nil)
+	for _, r := range img.Renditions {
+		newFiles = append(newFiles, r.ImagePath)
+	}
+	var oldFiles []string
+	for _, p := range imageFiles(stored, renditions) {
+		if !slices.Contains(newFiles, p) {
+			oldFiles = append(oldFiles, p)
+		}
+	}
+
+	return deleteFiles(i.storer, oldFiles)
+}
+
+// replaceImage Replaces the stored image with the new image and releases the blob of the stored image.
+func (i *images) replaceImage(ctx context.Context, stored *Image, img *Image) error {
+	if err := i.cardDao.ReplaceImage(ctx, img); err != nil {
+		return errors.Join(err, releaseBlob(ctx, i.cardDao, i.storer, img))
+	}
+
+	return releaseBlob(ctx, i.cardDao, i.storer, stored)
+}
+
+// imageFiles Returns the paths of the file and the rendition files of the image. The blob of a content addressed
+// image is not included, blobs are deleted once they are released by all images.
+func imageFiles(img *Image, renditions []*Rendition) []string {
+	var paths []string
+	if !img.BlobID.Valid {
+		paths = append(paths, img.ImagePath)
+	}
+	for _, r := range renditions {
+		paths = append(paths, r.ImagePath)
+	}
+
+	return paths
+}
+
+// deleteFiles Deletes the files with the given paths, missing files are ignored.
+func deleteFiles(storer storage.Storer, paths []string) error {
+	for _, p := range paths {
+		if err := storer.Delete(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete file %s %w", p, err)
+		}
+	}
+
+	return nil
+}
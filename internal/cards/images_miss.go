@@ -0,0 +1,65 @@
+package cards
+
+import (
+	"errors"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+)
+
+// Reasons why an image could not be imported.
+const (
+	MissCardNotFound  = "card_not_found"
+	MissImageNotFound = "image_not_found"
+	MissImageBroken   = "image_broken"
+	MissImageMismatch = "image_mismatch"
+)
+
+// ImageMiss A card face image that could not be imported and when it should be requested again.
+type ImageMiss struct {
+	ID          PrimaryID
+	CardID      PrimaryID
+	FaceID      PrimaryID
+	Lang        string
+	Variant     string
+	Reason      string
+	Attempts    int
+	LastAttempt time.Time
+	NextRetry   time.Time
+}
+
+// ImageMissCount The amount of missing images of a set with the same reason.
+type ImageMissCount struct {
+	Reason  string
+	SetCode string
+	Count   int
+}
+
+// missReason Returns the reason of the import error or an empty string if the error is not caused by a missing image.
+func missReason(err error) string {
+	var mismatch *MismatchError
+	switch {
+	case errors.As(err, &mismatch):
+		return MissImageMismatch
+	case errors.Is(err, ErrCardNotFound):
+		return MissCardNotFound
+	case errors.Is(err, ErrImageNotFound):
+		return MissImageNotFound
+	case errors.Is(err, ErrImageBroken):
+		return MissImageBroken
+	default:
+		return ""
+	}
+}
+
+// NextRetry Returns the time of the next attempt after the given amount of failed attempts. The configured backoff
+// is doubled with every attempt until the maximum backoff is reached.
+func NextRetry(lastAttempt time.Time, attempts int, cfg config.Images) time.Time {
+	maxBackoff := cfg.MaxRetryBackoffOrDefault()
+	backoff := cfg.RetryBackoffOrDefault()
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return lastAttempt.Add(min(backoff, maxBackoff))
+}
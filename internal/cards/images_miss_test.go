@@ -0,0 +1,32 @@
+package cards_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRetry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := config.Images{RetryBackoff: time.Hour, MaxRetryBackoff: 6 * time.Hour}
+
+	cases := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", attempts: 1, want: time.Hour},
+		{name: "second attempt doubles backoff", attempts: 2, want: 2 * time.Hour},
+		{name: "third attempt", attempts: 3, want: 4 * time.Hour},
+		{name: "limited by max backoff", attempts: 4, want: 6 * time.Hour},
+		{name: "many attempts", attempts: 1000, want: 6 * time.Hour},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, now.Add(tc.want), cards.NextRetry(now, tc.attempts, cfg))
+		})
+	}
+}
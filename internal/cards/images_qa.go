@@ -0,0 +1,259 @@
+package cards
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// hashChunks The perception hash is split into 16 chunks of 16 bits. Two hashes with a distance below 16 share at
+// least one chunk, so only images with a common chunk have to be compared.
+const hashChunks = 16
+
+// separateFaceLayouts Layouts with an image per face. The faces of other layouts e.g. split, flip or adventure cards
+// share the image of the card.
+var separateFaceLayouts = []string{"TRANSFORM", "MODAL_DFC", "REVERSIBLE_CARD"}
+
+// QAFinding A stored card image that probably shows the wrong card.
+type QAFinding struct {
+	Image    StoredImage
+	Other    StoredImage // the near-identical image, empty for placeholders
+	Distance int         // hamming distance of the perception hashes
+}
+
+// ImageQAReport The stored card images that are near-identical to an image they should differ from.
+type ImageQAReport struct {
+	Checked      int
+	Duplicates   []QAFinding // near-identical to the image of a different card
+	SameFaces    []QAFinding // both faces of a card with an image per face have a near-identical image
+	Placeholders []QAFinding // near-identical to a known placeholder e.g. the card back
+	Requeued     int
+}
+
+// HasFindings Returns true if any image is suspicious.
+func (r ImageQAReport) HasFindings() bool {
+	return len(r.Duplicates) > 0 || len(r.SameFaces) > 0 || len(r.Placeholders) > 0
+}
+
+// ImageQA Finds stored card images that show the wrong card by comparing their perception hashes.
+type ImageQA interface {
+	Inspect(ctx context.Context, fix bool) (ImageQAReport, error)
+}
+
+type imageQA struct {
+	cardDao *PostgresCardDao
+	storer  storage.Storer
+	cfg     config.Images
+}
+
+func NewImageQA(cardDao *PostgresCardDao, storer storage.Storer, cfg config.Images) ImageQA {
+	return &imageQA{
+		cardDao: cardDao,
+		storer:  storer,
+		cfg:     cfg,
+	}
+}
+
+// Inspect Reports images of the same variant that are near-identical to the image of a card with another name or
+// to the image of another face of the same card, and images that are near-identical to a configured placeholder.
+// Reprints with the same name and the faces of cards without an image per face are expected to share their image
+// and are not reported. With fix, the entries and files of all reported images are deleted, so the next image import
+// downloads them again.
+func (q *imageQA) Inspect(ctx context.Context, fix bool) (ImageQAReport, error) {
+	maxDistance := q.cfg.QA.MaxDistanceOrDefault()
+	if maxDistance >= hashChunks {
+		return ImageQAReport{}, fmt.Errorf("max distance must be less than %d but was %d", hashChunks, maxDistance)
+	}
+
+	placeholders, err := parsePlaceholders(q.cfg.QA.Placeholders)
+	if err != nil {
+		return ImageQAReport{}, err
+	}
+
+	imgs, err := q.cardDao.FindHashedImages(ctx)
+	if err != nil {
+		return ImageQAReport{}, fmt.Errorf("failed to get card images %w", err)
+	}
+
+	report := ImageQAReport{Checked: len(imgs)}
+	byVariant := map[string][]StoredImage{}
+	for _, img := range imgs {
+		if d, ok := matchPlaceholder(phash(img.Image), placeholders, maxDistance); ok {
+			report.Placeholders = append(report.Placeholders, QAFinding{Image: img, Distance: d})
+
+			// placeholders are similar to each other, they are not compared with the other images
+			continue
+		}
+		byVariant[img.Image.Variant] = append(byVariant[img.Image.Variant], img)
+	}
+
+	for _, variant := range slices.Sorted(maps.Keys(byVariant)) {
+		group := byVariant[variant]
+		hashes := make([][4]uint64, len(group))
+		for i, img := range group {
+			hashes[i] = phash(img.Image)
+		}
+
+		for _, p := range similarPairs(hashes, maxDistance) {
+			finding := QAFinding{Image: group[p.second], Other: group[p.first], Distance: p.distance}
+			img, other := finding.Image.Image, finding.Other.Image
+			switch {
+			case img.CardID == other.CardID && img.FaceID != other.FaceID &&
+				slices.Contains(separateFaceLayouts, strings.ToUpper(finding.Image.Layout)):
+				report.SameFaces = append(report.SameFaces, finding)
+			case img.CardID != other.CardID && !strings.EqualFold(finding.Image.Filter.Name, finding.Other.Filter.Name):
+				report.Duplicates = append(report.Duplicates, finding)
+			}
+		}
+	}
+
+	if fix {
+		requeued, err := q.requeue(ctx, report)
+		if err != nil {
+			return ImageQAReport{}, err
+		}
+		report.Requeued = requeued
+	}
+
+	return report, nil
+}
+
+// requeue Deletes all reported images and returns the amount of deleted images. Both images of a near-identical pair
+// are deleted, because it is unknown which one is wrong.
+func (q *imageQA) requeue(ctx context.Context, report ImageQAReport) (int, error) {
+	requeued := map[int64]bool{}
+	requeue := func(img *Image) error {
+		if img == nil || requeued[img.ID.Get()] {
+			return nil
+		}
+
+		renditions, err := q.cardDao.FindRenditions(ctx, img.ID.Get())
+		if err != nil {
+			return fmt.Errorf("failed to get renditions of image %s %w", img.ImagePath, err)
+		}
+		if err := requeueImage(ctx, q.cardDao, q.storer, img, true, renditions); err != nil {
+			return err
+		}
+		requeued[img.ID.Get()] = true
+		log.Debug().Msgf("requeued image %s", img.ImagePath)
+
+		return nil
+	}
+
+	for _, findings := range [][]QAFinding{report.Duplicates, report.SameFaces, report.Placeholders} {
+		for _, f := range findings {
+			if err := requeue(f.Image.Image); err != nil {
+				return 0, err
+			}
+			if err := requeue(f.Other.Image); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return len(requeued), nil
+}
+
+type similarPair struct {
+	first    int
+	second   int
+	distance int
+}
+
+// similarPairs Returns the index pairs of all hashes with a distance up to the given maximum, the first index is
+// always the lower one. The maximum must be less than hashChunks.
+func similarPairs(hashes [][4]uint64, maxDistance int) []similarPair {
+	var buckets [hashChunks]map[uint16][]int
+	for c := range buckets {
+		buckets[c] = map[uint16][]int{}
+	}
+
+	var pairs []similarPair
+	for i, h := range hashes {
+		for c := range hashChunks {
+			key := hashChunk(h, c)
+			for _, j := range buckets[c][key] {
+				// the pair was already compared in the first chunk both hashes have in common
+				if sharesChunkBefore(hashes[j], h, c) {
+					continue
+				}
+				if d := hashDistance(hashes[j], h); d <= maxDistance {
+					pairs = append(pairs, similarPair{first: j, second: i, distance: d})
+				}
+			}
+			buckets[c][key] = append(buckets[c][key], i)
+		}
+	}
+
+	return pairs
+}
+
+func sharesChunkBefore(a, b [4]uint64, chunk int) bool {
+	for c := range chunk {
+		if hashChunk(a, c) == hashChunk(b, c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashChunk Returns the 16 bits of the chunk with the given index.
+func hashChunk(h [4]uint64, chunk int) uint16 {
+	return uint16(h[chunk/4] >> (16 * (chunk % 4)))
+}
+
+func hashDistance(a, b [4]uint64) int {
+	d := 0
+	for i := range a {
+		d += bits.OnesCount64(a[i] ^ b[i])
+	}
+
+	return d
+}
+
+func phash(img *Image) [4]uint64 {
+	return [4]uint64{img.PHash1, img.PHash2, img.PHash3, img.PHash4}
+}
+
+// matchPlaceholder Returns the distance to the first placeholder within the maximum distance.
+func matchPlaceholder(h [4]uint64, placeholders [][4]uint64, maxDistance int) (int, bool) {
+	for _, p := range placeholders {
+		if d := hashDistance(h, p); d <= maxDistance {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// parsePlaceholders Parses perception hashes given as 64 hex characters, the first 16 characters are the first block.
+func parsePlaceholders(values []string) ([][4]uint64, error) {
+	var result [][4]uint64
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if len(v) != 64 {
+			return nil, fmt.Errorf("invalid placeholder hash %s, expected 64 hex characters", v)
+		}
+
+		var h [4]uint64
+		for i := range h {
+			block, err := strconv.ParseUint(v[i*16:(i+1)*16], 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid placeholder hash %s, %w", v, err)
+			}
+			h[i] = block
+		}
+		result = append(result, h)
+	}
+
+	return result, nil
+}
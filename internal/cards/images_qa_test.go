@@ -0,0 +1,124 @@
+package cards_test
+
+import (
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseHash = [4]uint64{0x0123456789abcdef, 0xfedcba9876543210, 0x0f1e2d3c4b5a6978, 0x8796a5b4c3d2e1f0}
+
+// flip Returns the hash with the given bits inverted, bit 0 is the lowest bit of the first block.
+func flip(h [4]uint64, bits ...int) [4]uint64 {
+	for _, b := range bits {
+		h[b/64] ^= 1 << (b % 64)
+	}
+
+	return h
+}
+
+func TestSimilarPairs(t *testing.T) {
+	cases := []struct {
+		name        string
+		other       [4]uint64
+		maxDistance int
+		want        []cards.SimilarPair
+	}{
+		{
+			name:        "identical",
+			other:       baseHash,
+			maxDistance: 4,
+			want:        []cards.SimilarPair{{First: 0, Second: 1, Distance: 0}},
+		},
+		{
+			name:        "distance equals max distance",
+			other:       flip(baseHash, 0, 70, 140, 210),
+			maxDistance: 4,
+			want:        []cards.SimilarPair{{First: 0, Second: 1, Distance: 4}},
+		},
+		{
+			name:        "distance above max distance",
+			other:       flip(baseHash, 0, 70, 140, 210, 250),
+			maxDistance: 4,
+		},
+		{
+			name:        "pair sharing all but one chunk is reported once",
+			other:       flip(baseHash, 5),
+			maxDistance: 4,
+			want:        []cards.SimilarPair{{First: 0, Second: 1, Distance: 1}},
+		},
+		{
+			name:        "differences at the chunk and block boundaries",
+			other:       flip(baseHash, 15, 16, 63, 64, 127, 128),
+			maxDistance: 6,
+			want:        []cards.SimilarPair{{First: 0, Second: 1, Distance: 6}},
+		},
+		{
+			name: "only the last chunk is shared",
+			other: flip(baseHash, 0, 16, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208,
+				224),
+			maxDistance: 15,
+			want:        []cards.SimilarPair{{First: 0, Second: 1, Distance: 15}},
+		},
+		{
+			name:        "no chunk is shared",
+			other:       flip(baseHash, 0, 16, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208, 224, 240),
+			maxDistance: 15,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := cards.SimilarPairs([][4]uint64{baseHash, tc.other}, tc.maxDistance)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSimilarPairsOfGroup(t *testing.T) {
+	hashes := [][4]uint64{
+		baseHash,
+		flip(baseHash, 200),
+		{^baseHash[0], ^baseHash[1], ^baseHash[2], ^baseHash[3]},
+		flip(baseHash, 1, 2),
+	}
+
+	got := cards.SimilarPairs(hashes, 3)
+
+	assert.Equal(t, []cards.SimilarPair{
+		{First: 0, Second: 1, Distance: 1},
+		{First: 0, Second: 3, Distance: 2},
+		{First: 1, Second: 3, Distance: 3},
+	}, got)
+}
+
+func TestParsePlaceholders(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		got, err := cards.ParsePlaceholders([]string{
+			" 0123456789abcdeffedcba98765432100f1e2d3c4b5a69788796A5B4C3D2E1F0 ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, [][4]uint64{baseHash}, got)
+	})
+
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "too short", value: "0123456789abcdef"},
+		{name: "too long", value: "0123456789abcdeffedcba98765432100f1e2d3c4b5a69788796a5b4c3d2e1f00"},
+		{name: "not hex", value: "0123456789abcdeffedcba98765432100f1e2d3c4b5a69788796a5b4c3d2e1fg"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cards.ParsePlaceholders([]string{tc.value})
+
+			require.Error(t, err)
+		})
+	}
+}
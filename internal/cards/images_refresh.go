@@ -0,0 +1,164 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// RefreshFilter Limits the refreshed images to sets and languages, empty values match all.
+type RefreshFilter struct {
+	SetCodes []string
+	Langs    []string
+}
+
+type RefreshReport struct {
+	Checked   int
+	Replaced  int
+	Unchanged int
+	Missing   int // the image is no longer available, the stored image is kept
+	Requeued  int // the new image is broken, the entry is deleted
+}
+
+// Refresh Requests every matching image again and replaces the file, hashes and renditions if the image has changed.
+// The stored validators are sent with the request, so unchanged images are usually not downloaded again. Images
+// without validators are downloaded and compared with the stored file. If the context is cancelled, the running
+// replacements are finished and the report collected so far is returned together with the error.
+func (i *images) Refresh(parentCtx context.Context, filter RefreshFilter) (RefreshReport, error) {
+	downloader, ok := i.downloader.(ConditionalImageDownloader)
+	if !ok {
+		return RefreshReport{}, fmt.Errorf("the image source doesn't support conditional requests")
+	}
+	if err := validateHashSizes(i.cfg); err != nil {
+		return RefreshReport{}, err
+	}
+
+	errg, ctx := errgroup.WithContext(parentCtx)
+	errg.SetLimit(i.cfg.WorkersOrDefault())
+
+	imgs, err := i.cardDao.FindStoredImages(ctx, filter.SetCodes, filter.Langs)
+	if err != nil {
+		return RefreshReport{}, fmt.Errorf("failed to get card images %w", err)
+	}
+
+	log.Info().Msgf("Refreshing %d images", len(imgs))
+
+	var mu sync.Mutex
+	report := RefreshReport{Checked: len(imgs)}
+	for _, si := range imgs {
+		errg.Go(func() error {
+			imgReport, err := i.refreshImage(ctx, downloader, si)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Replaced += imgReport.Replaced
+			report.Unchanged += imgReport.Unchanged
+			report.Missing += imgReport.Missing
+			report.Requeued += imgReport.Requeued
+
+			return nil
+		})
+	}
+
+	err = errg.Wait()
+	if parentCtx.Err() != nil {
+		return report, fmt.Errorf("image refresh interrupted %w", parentCtx.Err())
+	}
+	if err != nil {
+		return RefreshReport{}, err
+	}
+
+	return report, nil
+}
+
+func (i *images) refreshImage(ctx context.Context, downloader ConditionalImageDownloader,
+	si StoredImage) (RefreshReport, error) {
+	// request the language of the stored image, fallback images are upgraded with UpgradeFallbacks
+	requestFilter := si.Filter
+	requestFilter.Lang = si.Image.SourceLang
+	validators := Validators{ETag: si.Image.ETag, LastModified: si.Image.LastModified}
+
+	result, err := downloader.GetImageIfModified(ctx, requestFilter, validators)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImageNotModified):
+			return RefreshReport{Unchanged: 1}, nil
+		case missReason(err) != "":
+			log.Debug().Any("filter", requestFilter).Msg("card image no longer available")
+
+			return RefreshReport{Missing: 1}, nil
+		default:
+			return RefreshReport{}, fmt.Errorf("failed to download card image with filter %#v, %w", requestFilter, err)
+		}
+	}
+	defer aio.Close(result.File)
+
+	content, err := io.ReadAll(result.File)
+	if err != nil {
+		return RefreshReport{}, fmt.Errorf("failed to download card image with filter %#v, %w", requestFilter, err)
+	}
+
+	img := *si.Image
+	img.ETag = result.ETag
+	img.LastModified = result.LastModified
+	same, err := i.sameContent(img.ImagePath, content)
+	if err != nil {
+		return RefreshReport{}, err
+	}
+	if same {
+		if img.ETag != si.Image.ETag || img.LastModified != si.Image.LastModified {
+			if err := i.cardDao.UpdateValidators(ctx, &img); err != nil {
+				return RefreshReport{}, err
+			}
+		}
+
+		return RefreshReport{Unchanged: 1}, nil
+	}
+
+	writeCtx := context.WithoutCancel(ctx)
+	if result.Lang == "" {
+		result.Lang = requestFilter.Lang
+	}
+	result.File = io.NopCloser(bytes.NewReader(content))
+	if err := i.storeReplacement(writeCtx, si.Image, &img, si.Filter, result); err != nil {
+		if errors.Is(err, ErrImageBroken) {
+			log.Warn().Any("filter", requestFilter).Msg("card image broken, deleted entry")
+
+			return RefreshReport{Requeued: 1}, nil
+		}
+
+		return RefreshReport{}, fmt.Errorf("failed to replace image with filter %#v, %w", si.Filter, err)
+	}
+
+	log.Debug().Any("filter", requestFilter).Msgf("replaced changed image at %s", img.ImagePath)
+
+	return RefreshReport{Replaced: 1}, nil
+}
+
+// sameContent Checks if the stored file has the given content, a missing file never has the same content.
+func (i *images) sameContent(path string, content []byte) (bool, error) {
+	f, err := i.storer.Load(path)
+	if err != nil {
+		log.Debug().Err(err).Msgf("failed to load stored image %s", path)
+
+		return false, nil
+	}
+	defer aio.Close(f)
+
+	stored, err := io.ReadAll(f)
+	if err != nil {
+		return false, fmt.Errorf("failed to read stored image %s %w", path, err)
+	}
+
+	return bytes.Equal(stored, content), nil
+}
@@ -0,0 +1,136 @@
+package cards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// RehashFilter Limits the rehashed images to sets and languages, empty values match all.
+type RehashFilter struct {
+	SetCodes []string
+	Langs    []string
+}
+
+type RehashReport struct {
+	Total     int
+	Updated   int
+	Unchanged int
+	Failed    int
+}
+
+// Rehasher Recomputes the hashes of already stored images e.g. after the hash size has been changed.
+type Rehasher interface {
+	Rehash(ctx context.Context, filter RehashFilter) (RehashReport, error)
+}
+
+type rehasher struct {
+	cardDao *PostgresCardDao
+	storer  storage.Storer
+	cfg     config.Images
+}
+
+func NewRehasher(cardDao *PostgresCardDao, storer storage.Storer, cfg config.Images) Rehasher {
+	return &rehasher{
+		cardDao: cardDao,
+		storer:  storer,
+		cfg:     cfg,
+	}
+}
+
+// Rehash Loads every matching image from the storage and updates the hashes that changed or are missing.
+// Images that can't be loaded or decoded are reported as failed and skipped.
+func (r *rehasher) Rehash(ctx context.Context, filter RehashFilter) (RehashReport, error) {
+	if err := validateHashSizes(r.cfg); err != nil {
+		return RehashReport{}, err
+	}
+
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.SetLimit(r.cfg.WorkersOrDefault())
+
+	imgs, err := r.cardDao.FindImages(ctx, filter.SetCodes, filter.Langs)
+	if err != nil {
+		return RehashReport{}, fmt.Errorf("failed to get card images %w", err)
+	}
+
+	var mu sync.Mutex
+	report := RehashReport{Total: len(imgs)}
+	processed := 0
+	for _, img := range imgs {
+		errg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			updated, err := r.rehash(ctx, img)
+
+			mu.Lock()
+			defer mu.Unlock()
+			processed++
+			if processed%1000 == 0 || processed == report.Total {
+				log.Info().Msgf("Processed %d/%d images", processed, report.Total)
+			}
+
+			switch {
+			case errors.Is(err, ErrImageBroken):
+				log.Warn().Err(err).Int64("imageID", img.ID.Get()).Msg("card image broken")
+				report.Failed++
+
+				return nil
+			case err != nil:
+				return err
+			case updated:
+				report.Updated++
+			default:
+				report.Unchanged++
+			}
+
+			return nil
+		})
+	}
+
+	if err := errg.Wait(); err != nil {
+		return RehashReport{}, err
+	}
+
+	return report, nil
+}
+
+func (r *rehasher) rehash(ctx context.Context, img *Image) (bool, error) {
+	f, err := r.storer.Load(img.ImagePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to load image %s, %w", img.ImagePath, errors.Join(err, ErrImageBroken))
+	}
+	defer aio.Close(f)
+
+	decoded, err := DecodeImage(f, img.MimeType)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode image %s, %w", img.ImagePath, errors.Join(err, ErrImageBroken))
+	}
+
+	rehashed := *img
+	if err := computeHashes(&rehashed, decoded, r.cfg); err != nil {
+		return false, err
+	}
+	if sameHashes(img, &rehashed) {
+		return false, nil
+	}
+
+	if err := r.cardDao.UpdateHashes(ctx, &rehashed); err != nil {
+		return false, fmt.Errorf("failed to update hashes of image %d, %w", img.ID.Get(), err)
+	}
+
+	return true, nil
+}
+
+func sameHashes(a, b *Image) bool {
+	return a.PHash1 == b.PHash1 && a.PHash2 == b.PHash2 && a.PHash3 == b.PHash3 && a.PHash4 == b.PHash4 &&
+		slices.Equal(a.AHash, b.AHash) && slices.Equal(a.DHash, b.DHash)
+}
@@ -0,0 +1,27 @@
+package cards_test
+
+import (
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRehashInvalidHashSize(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  config.Images
+		want string
+	}{
+		{name: "not a multiple of 8", cfg: config.Images{AverageHashSize: 6}, want: "multiple of 8"},
+		{name: "more than 4096 bits", cfg: config.Images{DifferenceHashSize: 72}, want: "must not exceed 4096 bits"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cards.NewRehasher(nil, nil, tc.cfg).Rehash(t.Context(), cards.RehashFilter{})
+
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package cards
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/nfnt/resize"
+	"github.com/rs/zerolog/log"
+)
+
+// RenditionGray The name of the normalized grayscale rendition.
+const RenditionGray = "gray"
+
+const renditionsDir = "renditions"
+
+// renditionJpegQuality The jpeg quality of resized renditions.
+const renditionJpegQuality = 90
+
+// createRenditions Resizes the image to every configured width and creates the grayscale rendition if enabled.
+// The renditions are stored in a sub directory of the image directory named after the rendition. Widths that are
+// not smaller than the image are skipped, images are never enlarged. The stored renditions are deleted if a rendition
+// can't be stored.
+func createRenditions(storer storage.Storer, img image.Image, filter Filter, fileName string,
+	cfg config.Images) ([]Rendition, error) {
+	imgWidth := img.Bounds().Dx()
+	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+
+	var result []Rendition
+	for _, width := range cfg.RenditionWidths() {
+		if width >= imgWidth {
+			log.Debug().Msgf("skip rendition with width %d of %s, the image is only %d wide", width, fileName,
+				imgWidth)
+
+			continue
+		}
+
+		r, err := storeRendition(storer, Resize(img, width), renditionName(width), web.MimeTypeJpeg, filter, baseName)
+		if err != nil {
+			return nil, errors.Join(err, deleteRenditions(storer, result))
+		}
+		result = append(result, r)
+	}
+
+	if cfg.GrayscaleWidth > 0 {
+		gray := Grayscale(Resize(img, min(cfg.GrayscaleWidth, imgWidth)))
+		r, err := storeRendition(storer, gray, RenditionGray, web.MimeTypePng, filter, baseName)
+		if err != nil {
+			return nil, errors.Join(err, deleteRenditions(storer, result))
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// deleteRenditions Deletes the files of the renditions, missing files are ignored.
+func deleteRenditions(storer storage.Storer, renditions []Rendition) error {
+	paths := make([]string, 0, len(renditions))
+	for _, r := range renditions {
+		paths = append(paths, r.ImagePath)
+	}
+
+	return deleteFiles(storer, paths)
+}
+
+func storeRendition(storer storage.Storer, img image.Image, name, mimeType string, filter Filter,
+	baseName string) (Rendition, error) {
+	mt := web.NewMimeType(mimeType)
+	fileName, err := mt.BuildFilename(baseName)
+	if err != nil {
+		return Rendition{}, fmt.Errorf("failed to build rendition filename %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encodeImage(&buf, img, mt.Raw()); err != nil {
+		return Rendition{}, fmt.Errorf("failed to encode rendition %s of %s, %w", name, baseName, err)
+	}
+
+	storedFile, err := storer.Store(&buf, renditionPath(filter, name, fileName)...)
+	if err != nil {
+		return Rendition{}, fmt.Errorf("failed to store rendition %s with filter %#v, %w", name, filter, err)
+	}
+
+	return Rendition{
+		Name:      name,
+		ImagePath: storedFile.Path,
+		MimeType:  mt.Raw(),
+		Width:     img.Bounds().Dx(),
+		Height:    img.Bounds().Dy(),
+	}, nil
+}
+
+func encodeImage(buf *bytes.Buffer, img image.Image, mimeType string) error {
+	if mimeType == web.MimeTypePng {
+		return png.Encode(buf, img)
+	}
+
+	return jpeg.Encode(buf, img, &jpeg.Options{Quality: renditionJpegQuality})
+}
+
+// renditionName Returns the name of the rendition with the given width e.g. w146.
+func renditionName(width int) string {
+	return fmt.Sprintf("w%d", width)
+}
+
+// renditionPath Returns the storage path of the rendition, renditions are stored next to the image in
+// renditions/<name>/.
+func renditionPath(filter Filter, name, fileName string) []string {
+	path := imagePath(filter, fileName)
+	dir := slices.Clone(path[:len(path)-1])
+
+	return append(dir, renditionsDir, name, fileName)
+}
+
+// Resize Scales the image to the given width and keeps the aspect ratio.
+func Resize(img image.Image, width int) image.Image {
+	return resize.Resize(uint(max(width, 1)), 0, img, resize.Lanczos3)
+}
+
+// Grayscale Converts the image to grayscale and stretches the contrast, so the darkest pixel becomes black and the
+// brightest pixel becomes white.
+func Grayscale(img image.Image) *image.Gray {
+	bounds := img.Bounds()
+	gray := image.NewGray(bounds)
+	lowest, highest := uint8(255), uint8(0)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gray.Set(x, y, img.At(x, y))
+			v := gray.GrayAt(x, y).Y
+			lowest = min(lowest, v)
+			highest = max(highest, v)
+		}
+	}
+
+	if highest <= lowest {
+		return gray
+	}
+
+	spread := int(highest) - int(lowest)
+	for i, v := range gray.Pix {
+		gray.Pix[i] = uint8((int(v) - int(lowest)) * 255 / spread)
+	}
+
+	return gray
+}
@@ -0,0 +1,41 @@
+package cards_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResize(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 60))
+
+	img := cards.Resize(src, 20)
+
+	assert.Equal(t, image.Rect(0, 0, 20, 30), img.Bounds())
+}
+
+func TestGrayscale(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	src.Set(0, 0, color.RGBA{R: 50, G: 50, B: 50, A: 255})
+	src.Set(1, 0, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+	src.Set(2, 0, color.RGBA{R: 150, G: 150, B: 150, A: 255})
+
+	gray := cards.Grayscale(src)
+
+	assert.Equal(t, src.Bounds(), gray.Bounds())
+	assert.Equal(t, []uint8{0, 127, 255}, gray.Pix)
+}
+
+func TestGrayscaleSingleColor(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for i := range 4 {
+		src.Set(i%2, i/2, color.RGBA{R: 80, G: 80, B: 80, A: 255})
+	}
+
+	gray := cards.Grayscale(src)
+
+	assert.Equal(t, []uint8{80, 80, 80, 80}, gray.Pix)
+}
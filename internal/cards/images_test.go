@@ -2,11 +2,16 @@ package cards_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 	"github.com/konstantinfoerster/card-importer-go/internal/config"
@@ -303,10 +308,10 @@ func TestImageIntegration(t *testing.T) {
 				dir := t.TempDir()
 				store, err := storage.NewLocalStorage(config.Storage{Location: dir})
 				require.NoError(t, err)
-				importer := cards.NewImageImporter(cardDao, store, sclient)
+				importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Workers: 2})
 				createCard(t, runner.Connection(), tc.cards...)
 
-				report, err := importer.Import(cards.PageConfig{Page: 1, Size: 20})
+				report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
 				require.NoError(t, err)
 
 				assert.Equal(t, tc.want, report)
@@ -315,11 +320,895 @@ func TestImageIntegration(t *testing.T) {
 		}
 	})
 
+	t.Run("import images with filter", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		setService := cards.NewSetService(cards.NewSetDao(runner.Connection()))
+		require.NoError(t, setService.Import(&cards.CardSet{
+			Code:     "10E",
+			Name:     "Tenth Edition",
+			Released: time.Date(2007, time.July, 13, 0, 0, 0, 0, time.UTC),
+			Type:     "CORE",
+		}))
+		require.NoError(t, setService.Import(&cards.CardSet{
+			Code:     "9E",
+			Name:     "Ninth Edition",
+			Released: time.Date(2005, time.July, 29, 0, 0, 0, 0, time.UTC),
+			Type:     "CORE",
+		}))
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces:       []*cards.Face{{Name: "First"}},
+		}, cards.Card{
+			CardSetCode: "10E",
+			Number:      "2",
+			Name:        "Second",
+			Faces:       []*cards.Face{{Name: "Second"}},
+		}, cards.Card{
+			CardSetCode: "9E",
+			Number:      "3",
+			Name:        "Third",
+			Faces:       []*cards.Face{{Name: "Third"}},
+		})
+
+		cases := []struct {
+			name   string
+			filter cards.ImageFilter
+			want   cards.ImageReport
+		}{
+			{
+				name:   "no filter",
+				filter: cards.ImageFilter{},
+				want:   cards.ImageReport{TotalCards: 3, Imported: 6},
+			},
+			{
+				name:   "set codes",
+				filter: cards.ImageFilter{CardFilter: cards.CardFilter{SetCodes: []string{"9E"}}},
+				want:   cards.ImageReport{TotalCards: 1, Imported: 2},
+			},
+			{
+				name:   "languages",
+				filter: cards.ImageFilter{Langs: []string{"eng"}},
+				want:   cards.ImageReport{TotalCards: 3, Imported: 3},
+			},
+			{
+				name:   "numbers",
+				filter: cards.ImageFilter{CardFilter: cards.CardFilter{Numbers: []string{"1", "3"}}},
+				want:   cards.ImageReport{TotalCards: 2, Imported: 4},
+			},
+			{
+				name:   "rarity",
+				filter: cards.ImageFilter{CardFilter: cards.CardFilter{Rarities: []string{"MYTHIC"}}},
+				want:   cards.ImageReport{TotalCards: 0},
+			},
+			{
+				name: "released after",
+				filter: cards.ImageFilter{CardFilter: cards.CardFilter{
+					ReleasedAfter: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+				}},
+				want: cards.ImageReport{TotalCards: 2, Imported: 4},
+			},
+			{
+				name: "combined",
+				filter: cards.ImageFilter{
+					CardFilter: cards.CardFilter{SetCodes: []string{"10E"}, Numbers: []string{"2"}, Rarities: []string{"RARE"}},
+					Langs:      []string{"deu"},
+				},
+				want: cards.ImageReport{TotalCards: 1, Imported: 1},
+			},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				dir := t.TempDir()
+				store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+				require.NoError(t, err)
+				importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Workers: 2})
+				t.Cleanup(func() {
+					imgs, err := cardDao.GetImages()
+					require.NoError(t, err)
+					for _, img := range imgs {
+						require.NoError(t, cardDao.DeleteImage(context.Background(), img.ID.Get()))
+					}
+				})
+
+				report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, tc.filter)
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.want, report)
+				assert.Equal(t, tc.want.Imported, fileCount(t, dir))
+			})
+		}
+	})
+
+	t.Run("import images in batches after id", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces:       []*cards.Face{{Name: "First"}},
+		}, cards.Card{
+			CardSetCode: "10E",
+			Number:      "2",
+			Name:        "Second",
+			Faces:       []*cards.Face{{Name: "Second"}},
+		}, cards.Card{
+			CardSetCode: "9E",
+			Number:      "3",
+			Name:        "Third",
+			Faces:       []*cards.Face{{Name: "Third"}},
+		})
+		var ids []int64
+		for r := range cardDao.StreamCards(t.Context(), 0, 2, cards.CardFilter{}) {
+			require.NoError(t, r.Err)
+			ids = append(ids, r.Result.ID.Get())
+		}
+		require.Len(t, ids, 3)
+		assert.IsIncreasing(t, ids)
+
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Workers: 2})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{AfterID: ids[0], Size: 1}, cards.ImageFilter{})
+		require.NoError(t, err)
+
+		assert.Equal(t, cards.ImageReport{TotalCards: 3, Imported: 4}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		for _, img := range imgs {
+			assert.NotEqual(t, ids[0], img.CardID.Get())
+		}
+	})
+
+	t.Run("import images interrupted", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces:       []*cards.Face{{Name: "First"}},
+		}, cards.Card{
+			CardSetCode: "10E",
+			Number:      "2",
+			Name:        "Second",
+			Faces:       []*cards.Face{{Name: "Second"}},
+		}, cards.Card{
+			CardSetCode: "9E",
+			Number:      "3",
+			Name:        "Third",
+			Faces:       []*cards.Face{{Name: "Third"}},
+		})
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		downloader := &cancelDownloader{downloader: sclient, cancel: cancel, number: "2"}
+		importer := cards.NewImageImporter(cardDao, store, downloader, config.Images{Workers: 1})
+
+		report, err := importer.Import(ctx, cards.PageConfig{Size: 1}, cards.ImageFilter{Langs: []string{"deu"}})
+
+		var interrupted *cards.InterruptedError
+		require.ErrorAs(t, err, &interrupted)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, cards.ImageReport{TotalCards: 3, Imported: 1}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		require.Len(t, imgs, 1)
+		assert.Equal(t, imgs[0].CardID.Get(), interrupted.ResumeAfterID)
+		misses, err := cardDao.CountImageMisses(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, misses)
+		assert.Equal(t, 1, fileCount(t, dir))
+
+		report, err = importer.Import(t.Context(), cards.PageConfig{AfterID: interrupted.ResumeAfterID, Size: 1},
+			cards.ImageFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 3, Imported: 2}, report)
+	})
+
+	t.Run("import images with unsupported language", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{Langs: []string{"fra"}})
+
+		require.Error(t, err)
+	})
+
 	t.Run("import images set phash", func(t *testing.T) {
 		t.Cleanup(runner.Cleanup(t))
 		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
 		require.NoError(t, err)
-		importer := cards.NewImageImporter(cardDao, store, sclient)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Workers: 2})
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+
+		require.NotZero(t, imgs)
+		for _, img := range imgs {
+			assert.Greater(t, img.PHash1, uint64(0))
+			assert.Greater(t, img.PHash2, uint64(0))
+			assert.Greater(t, img.PHash3, uint64(0))
+			assert.Greater(t, img.PHash4, uint64(0))
+			// 16x16 bits
+			assert.Len(t, img.AHash, 4)
+			assert.Len(t, img.DHash, 4)
+			assert.Equal(t, 256, img.AverageHash().Bits())
+			assert.Equal(t, 256, img.DifferenceHash().Bits())
+		}
+	})
+
+	t.Run("import images with custom hash sizes", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+		cfg := config.Images{AverageHashSize: 8, DifferenceHashSize: 32}
+		importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+
+		require.NotZero(t, imgs)
+		for _, img := range imgs {
+			assert.Len(t, img.AHash, 1)
+			assert.Len(t, img.DHash, 16)
+		}
+	})
+
+	t.Run("import images with invalid hash size", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+
+		for _, cfg := range []config.Images{{AverageHashSize: 6}, {DifferenceHashSize: 72}} {
+			importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+
+			_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("import image variants", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		cfg := config.Images{Workers: 2, Variants: []string{"normal", "art_crop", "small"}}
+		importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+
+		// small is not available
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Imported: 4, Missing: 2}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		var paths []string
+		for _, img := range imgs {
+			paths = append(paths, img.Variant+":"+img.ImagePath)
+		}
+		faceID := imgs[0].FaceID.Get()
+		assert.ElementsMatch(t, []string{
+			fmt.Sprintf("normal:deu/10E/face-%d.jpg", faceID),
+			fmt.Sprintf("normal:eng/10E/face-%d.jpg", faceID),
+			fmt.Sprintf("art_crop:deu/10E/art_crop/face-%d.jpg", faceID),
+			fmt.Sprintf("art_crop:eng/10E/art_crop/face-%d.jpg", faceID),
+		}, paths)
+		assert.Equal(t, 4, fileCount(t, dir))
+	})
+
+	t.Run("import missing images with backoff", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		cfg := config.Images{Variants: []string{"small"}}
+
+		// small is not available
+		report, err := cards.NewImageImporter(cardDao, store, sclient, cfg).Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Missing: 2}, report)
+		misses, err := cardDao.CountImageMisses(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []cards.ImageMissCount{{Reason: cards.MissImageNotFound, SetCode: "10E", Count: 2}}, misses)
+
+		report, err = cards.NewImageImporter(cardDao, store, sclient, cfg).Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Deferred: 2}, report)
+
+		cfg.RetryBackoff = time.Nanosecond
+		report, err = cards.NewImageImporter(cardDao, store, sclient, cfg).Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Missing: 2}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		require.Empty(t, imgs)
+		paged, err := cardDao.PagedAfter(t.Context(), 0, 1, cards.CardFilter{})
+		require.NoError(t, err)
+		miss, err := cardDao.FindImageMiss(t.Context(), paged[0].Faces[0].ID.Get(), "deu", "small")
+		require.NoError(t, err)
+		assert.Equal(t, 2, miss.Attempts)
+
+		cfg.Variants = []string{"normal"}
+		report, err = cards.NewImageImporter(cardDao, store, sclient, cfg).Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Imported: 2}, report)
+	})
+
+	t.Run("import broken images again after backoff", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		cfg := config.Images{Renditions: []int{100}, RetryBackoff: time.Nanosecond}
+
+		report, err := cards.NewImageImporter(cardDao, store, &brokenDownloader{}, cfg).Import(t.Context(),
+			cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Missing: 2}, report)
+		assert.Equal(t, 0, fileCount(t, dir))
+
+		report, err = cards.NewImageImporter(cardDao, store, sclient, cfg).Import(t.Context(),
+			cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Imported: 2}, report)
+		assert.Equal(t, 4, fileCount(t, dir))
+	})
+
+	t.Run("import png image variant set phash", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Variants: []string{"png"}})
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "2",
+			Name:        "Second",
+			Faces: []*cards.Face{
+				{
+					Name: "Second",
+				},
+			},
+		})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Imported: 2}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		require.Len(t, imgs, 2)
+		for _, img := range imgs {
+			assert.Equal(t, "image/png", img.MimeType)
+			assert.Equal(t, ".png", filepath.Ext(img.ImagePath))
+			assert.Greater(t, img.PHash1, uint64(0))
+		}
+	})
+
+	t.Run("import images with renditions", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		// the image is smaller than 10000, therefore the rendition is skipped
+		cfg := config.Images{Variants: []string{"normal", "art_crop"}, Renditions: []int{100, 10000}, GrayscaleWidth: 64}
+		importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+
+		assert.Equal(t, cards.ImageReport{TotalCards: 1, Imported: 4}, report)
+		imgs, err := cardDao.GetImages()
+		require.NoError(t, err)
+		require.Len(t, imgs, 4)
+		renditions, err := cardDao.FindRenditions(t.Context(), 0)
+		require.NoError(t, err)
+		require.Len(t, renditions, 8)
+		faceID := imgs[0].FaceID.Get()
+		var paths []string
+		for _, r := range renditions {
+			paths = append(paths, r.Name+":"+r.ImagePath)
+			assert.Positive(t, r.Height)
+			if r.Name == cards.RenditionGray {
+				assert.Equal(t, "image/png", r.MimeType)
+				assert.Equal(t, 64, r.Width)
+			} else {
+				assert.Equal(t, "image/jpeg", r.MimeType)
+				assert.Equal(t, 100, r.Width)
+			}
+			assert.FileExists(t, filepath.Join(dir, r.ImagePath))
+		}
+		assert.ElementsMatch(t, []string{
+			fmt.Sprintf("w100:deu/10E/renditions/w100/face-%d.jpg", faceID),
+			fmt.Sprintf("w100:eng/10E/renditions/w100/face-%d.jpg", faceID),
+			fmt.Sprintf("w100:deu/10E/art_crop/renditions/w100/face-%d.jpg", faceID),
+			fmt.Sprintf("w100:eng/10E/art_crop/renditions/w100/face-%d.jpg", faceID),
+			fmt.Sprintf("gray:deu/10E/renditions/gray/face-%d.png", faceID),
+			fmt.Sprintf("gray:eng/10E/renditions/gray/face-%d.png", faceID),
+			fmt.Sprintf("gray:deu/10E/art_crop/renditions/gray/face-%d.png", faceID),
+			fmt.Sprintf("gray:eng/10E/art_crop/renditions/gray/face-%d.png", faceID),
+		}, paths)
+		assert.Equal(t, 12, fileCount(t, dir))
+
+		audit, err := cards.NewImageVerifier(cardDao, store, cfg).Verify(t.Context(), false)
+		require.NoError(t, err)
+		assert.False(t, audit.HasFindings())
+	})
+
+	t.Run("upgrade fallback images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		downloader := &langDownloader{downloader: sclient, missingLang: "deu"}
+		cfg := config.Images{Renditions: []int{100}}
+		importer := cards.NewImageImporter(cardDao, store, downloader, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		fallback := findImage(t, cardDao, "deu")
+		assert.True(t, fallback.Fallback)
+		assert.Equal(t, "eng", fallback.SourceLang)
+		assert.Equal(t, findImage(t, cardDao, "eng").PHash1, fallback.PHash1)
+
+		report, err := importer.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{Checked: 1, Missing: 1}, report)
+
+		downloader.missingLang = ""
+		report, err = importer.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{Checked: 1, Upgraded: 1}, report)
+
+		upgraded := findImage(t, cardDao, "deu")
+		assert.False(t, upgraded.Fallback)
+		assert.Equal(t, "deu", upgraded.SourceLang)
+		assert.Equal(t, fallback.ID, upgraded.ID)
+		assert.Equal(t, fallback.ImagePath, upgraded.ImagePath)
+		assert.NotEqual(t, fallback.PHash1, upgraded.PHash1)
+		renditions, err := cardDao.FindRenditions(t.Context(), upgraded.ID.Get())
+		require.NoError(t, err)
+		assert.Len(t, renditions, 1)
+		assert.Equal(t, 4, fileCount(t, dir))
+
+		report, err = importer.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{}, report)
+	})
+
+	t.Run("upgrade fallback images with replace storage", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir, Mode: config.REPLACE})
+		require.NoError(t, err)
+		downloader := &langDownloader{downloader: sclient, missingLang: "deu"}
+		cfg := config.Images{Renditions: []int{100}}
+		importer := cards.NewImageImporter(cardDao, store, downloader, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		fallback := findImage(t, cardDao, "deu")
+		require.True(t, fallback.Fallback)
+
+		downloader.missingLang = ""
+		report, err := importer.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{Checked: 1, Upgraded: 1}, report)
+
+		upgraded := findImage(t, cardDao, "deu")
+		assert.False(t, upgraded.Fallback)
+		assert.Equal(t, fallback.ImagePath, upgraded.ImagePath)
+		assert.NotEqual(t, fallback.PHash1, upgraded.PHash1)
+		assert.Equal(t, 4, fileCount(t, dir))
+	})
+
+	t.Run("upgrade fallback images with broken image", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		cfg := config.Images{Renditions: []int{100}}
+		importer := cards.NewImageImporter(cardDao, store, &langDownloader{downloader: sclient, missingLang: "deu"},
+			cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		require.True(t, findImage(t, cardDao, "deu").Fallback)
+
+		broken := cards.NewImageImporter(cardDao, store, &brokenDownloader{}, cfg)
+		report, err := broken.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{Checked: 1, Requeued: 1}, report)
+
+		imgs, err := cardDao.FindImages(t.Context(), nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, imgs, 1)
+		assert.Equal(t, 2, fileCount(t, dir))
+	})
+
+	t.Run("refresh changed images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		cfg := config.Images{Renditions: []int{100}}
+		importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		imported := findImage(t, cardDao, "deu")
+		assert.NotEmpty(t, imported.LastModified)
+
+		report, err := importer.Refresh(t.Context(), cards.RefreshFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Unchanged: 1}, report)
+
+		withoutValidators := *imported
+		withoutValidators.LastModified = ""
+		withoutValidators.ETag = ""
+		require.NoError(t, cardDao.UpdateValidators(t.Context(), &withoutValidators))
+		report, err = importer.Refresh(t.Context(), cards.RefreshFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Unchanged: 1}, report)
+		assert.Equal(t, imported.LastModified, findImage(t, cardDao, "deu").LastModified)
+
+		changed := cards.NewImageImporter(cardDao, store, &changedDownloader{downloader: sclient, lang: "eng"}, cfg)
+		report, err = changed.Refresh(t.Context(), cards.RefreshFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Replaced: 1}, report)
+
+		replaced := findImage(t, cardDao, "deu")
+		assert.Equal(t, imported.ID, replaced.ID)
+		assert.Equal(t, imported.ImagePath, replaced.ImagePath)
+		assert.False(t, replaced.Fallback)
+		assert.NotEqual(t, imported.PHash1, replaced.PHash1)
+		renditions, err := cardDao.FindRenditions(t.Context(), replaced.ID.Get())
+		require.NoError(t, err)
+		assert.Len(t, renditions, 1)
+		assert.Equal(t, 2, fileCount(t, dir))
+
+		report, err = importer.Refresh(t.Context(), cards.RefreshFilter{SetCodes: []string{"9E"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{}, report)
+
+		report, err = importer.Refresh(t.Context(), cards.RefreshFilter{SetCodes: []string{"9E", "10E"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Unchanged: 1}, report)
+	})
+
+	t.Run("refresh changed images with replace storage", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir, Mode: config.REPLACE})
+		require.NoError(t, err)
+		cfg := config.Images{Renditions: []int{100}}
+		importer := cards.NewImageImporter(cardDao, store, sclient, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		imported := findImage(t, cardDao, "deu")
+
+		changed := cards.NewImageImporter(cardDao, store, &changedDownloader{downloader: sclient, lang: "eng"}, cfg)
+		report, err := changed.Refresh(t.Context(), cards.RefreshFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Replaced: 1}, report)
+
+		replaced := findImage(t, cardDao, "deu")
+		assert.Equal(t, imported.ImagePath, replaced.ImagePath)
+		assert.NotEqual(t, imported.PHash1, replaced.PHash1)
+		assert.Equal(t, 2, fileCount(t, dir))
+	})
+
+	t.Run("import content addressed images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		downloader := &langDownloader{downloader: sclient, missingLang: "deu"}
+		cfg := config.Images{ContentAddressed: true}
+		importer := cards.NewImageImporter(cardDao, store, downloader, cfg)
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Imported)
+		eng := findImage(t, cardDao, "eng")
+		fallback := findImage(t, cardDao, "deu")
+		require.True(t, eng.BlobID.Valid)
+		assert.Equal(t, eng.BlobID, fallback.BlobID)
+		assert.Equal(t, eng.ImagePath, fallback.ImagePath)
+		assert.Regexp(t, `^blobs/[0-9a-f]{2}/[0-9a-f]{64}\.jpg$`, eng.ImagePath)
+		blob, err := cardDao.FindBlob(t.Context(), eng.BlobID.Get())
+		require.NoError(t, err)
+		assert.Equal(t, 2, blob.RefCount)
+		assert.Equal(t, 1, fileCount(t, dir))
+
+		downloader.missingLang = ""
+		fbReport, err := importer.UpgradeFallbacks(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, cards.FallbackReport{Checked: 1, Upgraded: 1}, fbReport)
+		upgraded := findImage(t, cardDao, "deu")
+		assert.NotEqual(t, eng.BlobID, upgraded.BlobID)
+		blob, err = cardDao.FindBlob(t.Context(), eng.BlobID.Get())
+		require.NoError(t, err)
+		assert.Equal(t, 1, blob.RefCount)
+		assert.Equal(t, 2, fileCount(t, dir))
+
+		// the german image is replaced with the english image, the unused german blob is deleted
+		changed := cards.NewImageImporter(cardDao, store, &changedDownloader{downloader: sclient, lang: "eng"}, cfg)
+		refreshReport, err := changed.Refresh(t.Context(), cards.RefreshFilter{Langs: []string{"deu"}})
+		require.NoError(t, err)
+		assert.Equal(t, cards.RefreshReport{Checked: 1, Replaced: 1}, refreshReport)
+		assert.Equal(t, eng.BlobID, findImage(t, cardDao, "deu").BlobID)
+		_, err = cardDao.FindBlob(t.Context(), upgraded.BlobID.Get())
+		require.ErrorIs(t, err, cards.ErrEntryNotFound)
+		blob, err = cardDao.FindBlob(t.Context(), eng.BlobID.Get())
+		require.NoError(t, err)
+		assert.Equal(t, 2, blob.RefCount)
+		assert.Equal(t, 1, fileCount(t, dir))
+	})
+
+	t.Run("import unsupported image variant", func(t *testing.T) {
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Variants: []string{"unknown"}})
+
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+
+		require.Error(t, err)
+	})
+
+	t.Run("identify card image", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		store, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		identifier := cards.NewIdentifier(cardDao)
+
+		cases := []struct {
+			name        string
+			file        string
+			mimeType    string
+			maxDistance int
+		}{
+			{name: "same image", file: "cardImageDe.jpg", mimeType: "image/jpeg", maxDistance: 0},
+			{name: "downscaled image", file: "cardImage.png", mimeType: "image/png", maxDistance: 20},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				f, err := os.Open(filepath.Join("testdata", "images", tc.file))
+				require.NoError(t, err)
+				defer f.Close()
+
+				matches, err := identifier.Identify(t.Context(), f, tc.mimeType, cards.VariantNormal, 1)
+
+				require.NoError(t, err)
+				require.Len(t, matches, 1)
+				assert.Equal(t, "10E", matches[0].SetCode)
+				assert.Equal(t, "1", matches[0].Number)
+				assert.Equal(t, "First", matches[0].Name)
+				assert.LessOrEqual(t, matches[0].Distance, tc.maxDistance)
+			})
+		}
+
+		t.Run("unknown variant", func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", "images", "cardImageDe.jpg"))
+			require.NoError(t, err)
+			defer f.Close()
+
+			matches, err := identifier.Identify(t.Context(), f, "image/jpeg", cards.VariantArtCrop, 5)
+
+			require.NoError(t, err)
+			assert.Empty(t, matches)
+		})
+	})
+
+	t.Run("find similar images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		for _, number := range []string{"1", "2", "3", "4"} {
+			createCard(t, runner.Connection(), cards.Card{
+				CardSetCode: "10E",
+				Number:      number,
+				Name:        "Card " + number,
+				Faces: []*cards.Face{
+					{
+						Name: "Card " + number,
+					},
+				},
+			})
+		}
+		addImage := func(number, variant string, phash [4]uint64) {
+			c, err := cardDao.FindUniqueCard("10E", number)
+			require.NoError(t, err)
+			faces, err := cardDao.FindAssignedFaces(c.ID.Get())
+			require.NoError(t, err)
+			require.NoError(t, cardDao.AddImage(t.Context(), &cards.Image{
+				Lang:      "deu",
+				Variant:   variant,
+				CardID:    c.ID,
+				FaceID:    faces[0].ID,
+				ImagePath: "deu/10E/" + variant + "/" + number + ".jpg",
+				MimeType:  "image/jpeg",
+				PHash1:    phash[0],
+				PHash2:    phash[1],
+				PHash3:    phash[2],
+				PHash4:    phash[3],
+			}))
+		}
+		addImage("1", cards.VariantNormal, [4]uint64{0b111, 0, 0, 0})
+		addImage("2", cards.VariantNormal, [4]uint64{0, 0, 0, 0})
+		addImage("3", cards.VariantNormal, [4]uint64{^uint64(0), 0, 0, 0b111111})
+		addImage("4", cards.VariantNormal, [4]uint64{0, 0, 0b111 << 61, 0})
+		addImage("1", cards.VariantArtCrop, [4]uint64{0, 0, 0, 0})
+		distances := func(matches []cards.ImageMatch) []string {
+			var result []string
+			for _, m := range matches {
+				result = append(result, fmt.Sprintf("%s:%d", m.Number, m.Distance))
+			}
+
+			return result
+		}
+
+		matches, err := cardDao.FindSimilarImages(t.Context(), []uint64{0, 0, 0, 0}, cards.VariantNormal, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2:0", "1:3", "4:3", "3:70"}, distances(matches))
+		assert.Equal(t, "Card 2", matches[0].Name)
+		assert.Equal(t, "deu", matches[0].Lang)
+
+		matches, err = cardDao.FindSimilarImages(t.Context(), []uint64{0, 0, 0, 0}, cards.VariantNormal, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2:0", "1:3"}, distances(matches))
+
+		matches, err = cardDao.FindSimilarImages(t.Context(), []uint64{0, 0, 0, 0b111111}, cards.VariantNormal, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2:6"}, distances(matches))
+	})
+
+	t.Run("rehash images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
 		createCard(t, runner.Connection(), cards.Card{
 			CardSetCode: "10E",
 			Number:      "1",
@@ -329,20 +1218,165 @@ func TestImageIntegration(t *testing.T) {
 					Name: "First",
 				},
 			},
+		}, cards.Card{
+			CardSetCode: "9E",
+			Number:      "3",
+			Name:        "Third",
+			Faces: []*cards.Face{
+				{
+					Name: "Third",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		imported, err := cardDao.GetImages()
+		require.NoError(t, err)
+		require.Len(t, imported, 4)
+
+		t.Run("unchanged", func(t *testing.T) {
+			report, err := cards.NewRehasher(cardDao, store, config.Images{}).Rehash(t.Context(), cards.RehashFilter{})
+
+			require.NoError(t, err)
+			assert.Equal(t, cards.RehashReport{Total: 4, Unchanged: 4}, report)
+		})
+
+		t.Run("missing hashes", func(t *testing.T) {
+			_, err := runner.Connection().Conn.Exec(t.Context(), "UPDATE card_image SET ahash = NULL, dhash = NULL")
+			require.NoError(t, err)
+
+			report, err := cards.NewRehasher(cardDao, store, config.Images{Workers: 2}).Rehash(t.Context(), cards.RehashFilter{})
+
+			require.NoError(t, err)
+			assert.Equal(t, cards.RehashReport{Total: 4, Updated: 4}, report)
+			imgs, err := cardDao.GetImages()
+			require.NoError(t, err)
+			assert.ElementsMatch(t, imported, imgs)
+		})
+
+		t.Run("filter by set and language", func(t *testing.T) {
+			cfg := config.Images{AverageHashSize: 8}
+			filter := cards.RehashFilter{SetCodes: []string{"9E"}, Langs: []string{"deu"}}
+
+			report, err := cards.NewRehasher(cardDao, store, cfg).Rehash(t.Context(), filter)
+
+			require.NoError(t, err)
+			assert.Equal(t, cards.RehashReport{Total: 1, Updated: 1}, report)
+			imgs, err := cardDao.FindImages(t.Context(), filter.SetCodes, filter.Langs)
+			require.NoError(t, err)
+			require.Len(t, imgs, 1)
+			assert.Len(t, imgs[0].AHash, 1)
+		})
+
+		t.Run("filter by multiple sets", func(t *testing.T) {
+			filter := cards.RehashFilter{SetCodes: []string{"10E", "9E"}, Langs: []string{"deu"}}
+
+			report, err := cards.NewRehasher(cardDao, store, config.Images{}).Rehash(t.Context(), filter)
+
+			require.NoError(t, err)
+			assert.Equal(t, cards.RehashReport{Total: 2, Updated: 1, Unchanged: 1}, report)
+		})
+
+		t.Run("broken file", func(t *testing.T) {
+			err := os.WriteFile(filepath.Join(dir, imported[0].ImagePath), []byte("broken"), 0600)
+			require.NoError(t, err)
+
+			report, err := cards.NewRehasher(cardDao, store, config.Images{}).Rehash(t.Context(),
+				cards.RehashFilter{SetCodes: []string{"10E"}})
+
+			require.NoError(t, err)
+			assert.Equal(t, cards.RehashReport{Total: 2, Unchanged: 1, Failed: 1}, report)
 		})
+	})
 
-		_, err = importer.Import(cards.PageConfig{Page: 1, Size: 20})
+	t.Run("verify images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		}, cards.Card{
+			CardSetCode: "10E",
+			Number:      "2",
+			Name:        "Second",
+			Faces: []*cards.Face{
+				{
+					Name: "Second",
+				},
+			},
+		}, cards.Card{
+			CardSetCode: "9E",
+			Number:      "3",
+			Name:        "Third",
+			Faces: []*cards.Face{
+				{
+					Name: "Third",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
 		require.NoError(t, err)
 		imgs, err := cardDao.GetImages()
 		require.NoError(t, err)
+		require.Len(t, imgs, 6)
+		verifier := cards.NewImageVerifier(cardDao, store, config.Images{Workers: 2})
 
-		require.NotZero(t, imgs)
-		for _, img := range imgs {
-			assert.Greater(t, img.PHash1, uint64(0))
-			assert.Greater(t, img.PHash2, uint64(0))
-			assert.Greater(t, img.PHash3, uint64(0))
-			assert.Greater(t, img.PHash4, uint64(0))
+		audit, err := verifier.Verify(t.Context(), false)
+		require.NoError(t, err)
+		assert.False(t, audit.HasFindings())
+		assert.Equal(t, 6, audit.Checked)
+
+		pngImg, err := os.ReadFile(filepath.Join("testdata", "images", "cardImage.png"))
+		require.NoError(t, err)
+		jpegImg, err := os.ReadFile(filepath.Join("testdata", "images", "cardImageDe.jpg"))
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(filepath.Join(dir, imgs[0].ImagePath)))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, imgs[1].ImagePath), []byte{}, 0600))
+		// the jpeg header is detected, but the content is truncated
+		require.NoError(t, os.WriteFile(filepath.Join(dir, imgs[2].ImagePath), jpegImg[:100], 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, imgs[3].ImagePath), pngImg, 0600))
+		_, err = store.Store(strings.NewReader("orphan"), "deu", "10E", "orphan.jpg")
+		require.NoError(t, err)
+		// files outside of the image layout, temporary and reserved files of a running import are ignored
+		_, err = store.Store(strings.NewReader("{}"), "downloads", "AllPrintings.json")
+		require.NoError(t, err)
+		_, err = store.Store(strings.NewReader("partial"), "deu", "10E", ".new.jpg-123.tmp")
+		require.NoError(t, err)
+		_, err = store.Store(strings.NewReader(""), "deu", "10E", "reserved.jpg")
+		require.NoError(t, err)
+		want := cards.ImageAudit{
+			Checked:        6,
+			MissingFiles:   []string{imgs[0].ImagePath},
+			EmptyFiles:     []string{imgs[1].ImagePath},
+			BrokenFiles:    []string{imgs[2].ImagePath},
+			MimeMismatches: []string{imgs[3].ImagePath},
+			OrphanFiles:    []string{"deu/10E/orphan.jpg"},
 		}
+
+		audit, err = verifier.Verify(t.Context(), false)
+		require.NoError(t, err)
+		assert.Equal(t, want, audit)
+
+		want.DeletedOrphans = 1
+		want.Requeued = 4
+		audit, err = verifier.Verify(t.Context(), true)
+		require.NoError(t, err)
+		assert.Equal(t, want, audit)
+
+		remaining, err := cardDao.GetImages()
+		require.NoError(t, err)
+		assert.Len(t, remaining, 2)
+		assert.Equal(t, 5, fileCount(t, dir))
+		assert.FileExists(t, filepath.Join(dir, "downloads", "AllPrintings.json"))
 	})
 
 	t.Run("import images multiple times", func(t *testing.T) {
@@ -350,7 +1384,7 @@ func TestImageIntegration(t *testing.T) {
 		dir := t.TempDir()
 		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
 		require.NoError(t, err)
-		importer := cards.NewImageImporter(cardDao, store, sclient)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{Workers: 2})
 		createCard(t, runner.Connection(), cards.Card{
 			CardSetCode: "10E",
 			Number:      "1",
@@ -361,15 +1395,290 @@ func TestImageIntegration(t *testing.T) {
 				},
 			},
 		})
-		_, err = importer.Import(cards.PageConfig{Page: 1, Size: 20})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
 		require.NoError(t, err)
 
-		report, err := importer.Import(cards.PageConfig{Page: 1, Size: 20})
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
 		require.NoError(t, err)
 
 		assert.Equal(t, 2, report.Skipped)
 		assert.Equal(t, 2, fileCount(t, dir))
 	})
+
+	t.Run("reject image of another language", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+		// the german lookup resolves to the english printing
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "printing",
+			Name:        "Printing",
+			Faces: []*cards.Face{
+				{
+					Name: "Printing",
+				},
+			},
+		})
+
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Imported)
+		deu := findImage(t, cardDao, "deu")
+		assert.True(t, deu.Fallback)
+		assert.Equal(t, "eng", deu.SourceLang)
+		assert.True(t, deu.Verified)
+		assert.Equal(t, "lang de != en", deu.Mismatch)
+		eng := findImage(t, cardDao, "eng")
+		assert.False(t, eng.Fallback)
+		assert.True(t, eng.Verified)
+		assert.Empty(t, eng.Mismatch)
+	})
+
+	t.Run("inspect images", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+		// all test images have the same perception hash
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "multiFace",
+			Name:        "FirstFace // SecondFace",
+			Layout:      "TRANSFORM",
+			Faces: []*cards.Face{
+				{
+					Name: "FirstFace",
+				},
+				{
+					Name: "SecondFace",
+				},
+			},
+		}, cards.Card{
+			CardSetCode: "10E",
+			Number:      "1",
+			Name:        "First",
+			Faces: []*cards.Face{
+				{
+					Name: "First",
+				},
+			},
+		})
+		_, err = importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+
+		report, err := cards.NewImageQA(cardDao, store, config.Images{}).Inspect(t.Context(), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, report.Checked)
+		assert.Len(t, report.SameFaces, 4)
+		assert.Len(t, report.Duplicates, 8)
+		assert.Empty(t, report.Placeholders)
+		assert.Equal(t, 0, report.Requeued)
+
+		imgs, err := cardDao.FindImages(t.Context(), nil, nil)
+		require.NoError(t, err)
+		img := imgs[0]
+		placeholder := fmt.Sprintf("%016x%016x%016x%016x", img.PHash1, img.PHash2, img.PHash3, img.PHash4)
+		cfg := config.Images{QA: config.ImageQA{Placeholders: []string{placeholder}}}
+
+		report, err = cards.NewImageQA(cardDao, store, cfg).Inspect(t.Context(), true)
+
+		require.NoError(t, err)
+		assert.Len(t, report.Placeholders, 6)
+		assert.Empty(t, report.SameFaces)
+		assert.Empty(t, report.Duplicates)
+		assert.Equal(t, 6, report.Requeued)
+		imgs, err = cardDao.FindImages(t.Context(), nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, imgs)
+		assert.Equal(t, 0, fileCount(t, dir))
+	})
+
+	t.Run("inspect split card", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		importer := cards.NewImageImporter(cardDao, store, sclient, config.Images{})
+		// the faces of a split card share the image of the card
+		createCard(t, runner.Connection(), cards.Card{
+			CardSetCode: "10E",
+			Number:      "split",
+			Name:        "Fire // Ice",
+			Layout:      "SPLIT",
+			Faces: []*cards.Face{
+				{
+					Name: "Fire",
+				},
+				{
+					Name: "Ice",
+				},
+			},
+		})
+		report, err := importer.Import(t.Context(), cards.PageConfig{Size: 20}, cards.ImageFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Imported)
+
+		qaReport, err := cards.NewImageQA(cardDao, store, config.Images{}).Inspect(t.Context(), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, qaReport.Checked)
+		assert.False(t, qaReport.HasFindings())
+		assert.Equal(t, 0, qaReport.Requeued)
+	})
+
+	t.Run("import symbols", func(t *testing.T) {
+		t.Cleanup(runner.Cleanup(t))
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(config.Storage{Location: dir})
+		require.NoError(t, err)
+		setDao := cards.NewSetDao(runner.Connection())
+		symbolDao := cards.NewSymbolDao(runner.Connection())
+		setService := cards.NewSetService(setDao)
+		for _, code := range []string{"SYA", "SYB"} {
+			err = setService.Import(&cards.CardSet{Code: code, Name: code, Type: "CORE"})
+			require.NoError(t, err)
+		}
+		downloader := &symbolDownloader{
+			icons: []cards.SymbolSource{
+				{Code: "SYA", URL: "/icons/shared.svg"},
+				{Code: "SYB", URL: "/icons/shared.svg"},
+				{Code: "UNKNOWN", URL: "/icons/unknown.svg"},
+			},
+			symbols: []cards.SymbolSource{
+				{Code: "{W}", Name: "one white mana", URL: "/symbols/W.svg"},
+				{Code: "{T}", Name: "tap this permanent", URL: "/symbols/missing.svg"},
+				{Code: "{Q}", Name: "untap this permanent", URL: "/symbols/Q.svg?1700000000"},
+			},
+		}
+		_, err = store.Store(strings.NewReader("<svg/>"), cards.SymbolsDir, "cost", "Q.svg")
+		require.NoError(t, err)
+		importer := cards.NewSymbolImporter(setDao, symbolDao, store, downloader)
+
+		report, err := importer.Import(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, cards.SymbolReport{SetIcons: 2, Symbols: 2, Skipped: 1, Missing: 1}, report)
+		assert.Equal(t, 3, fileCount(t, dir))
+		assert.Equal(t, []string{"/icons/shared.svg", "/symbols/W.svg", "/symbols/missing.svg"}, downloader.downloaded)
+		icons, err := setDao.FindSetIcons(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "symbols/sets/shared.svg", icons["SYA"])
+		assert.Equal(t, "symbols/sets/shared.svg", icons["SYB"])
+		symbols, err := symbolDao.FindSymbols(t.Context())
+		require.NoError(t, err)
+		require.Len(t, symbols, 2)
+		assert.Equal(t, "{Q}", symbols[0].Symbol)
+		assert.Equal(t, "symbols/cost/Q.svg", symbols[0].ImagePath)
+		assert.Equal(t, web.MimeTypeSvg, symbols[0].MimeType)
+		assert.Equal(t, "{W}", symbols[1].Symbol)
+		assert.Equal(t, "symbols/cost/W.svg", symbols[1].ImagePath)
+		assert.Equal(t, web.MimeTypeSvg, symbols[1].MimeType)
+
+		report, err = importer.Import(t.Context())
+
+		require.NoError(t, err)
+		assert.Equal(t, cards.SymbolReport{Skipped: 5, Missing: 1}, report)
+	})
+}
+
+// symbolDownloader Returns static set icons and symbols, images with missing in the url are not found.
+type symbolDownloader struct {
+	icons      []cards.SymbolSource
+	symbols    []cards.SymbolSource
+	downloaded []string
+}
+
+func (d *symbolDownloader) FindSetIcons(_ context.Context) ([]cards.SymbolSource, error) {
+	return d.icons, nil
+}
+
+func (d *symbolDownloader) FindSymbols(_ context.Context) ([]cards.SymbolSource, error) {
+	return d.symbols, nil
+}
+
+func (d *symbolDownloader) GetSymbolImage(_ context.Context, url string) (*cards.ImageResult, error) {
+	d.downloaded = append(d.downloaded, url)
+	if strings.Contains(url, "missing") {
+		return nil, cards.ErrImageNotFound
+	}
+
+	return &cards.ImageResult{
+		MimeType: web.NewMimeType(web.MimeTypeSvg),
+		File:     io.NopCloser(strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg"/>`)),
+	}, nil
+}
+
+// langDownloader Reports the images of one language as not found.
+type langDownloader struct {
+	downloader  cards.ImageDownloader
+	missingLang string
+}
+
+func (d *langDownloader) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResult, error) {
+	if f.Lang == d.missingLang {
+		return nil, cards.ErrImageNotFound
+	}
+
+	return d.downloader.GetImage(ctx, f)
+}
+
+// cancelDownloader Cancels the import when the image of the card with the given number is requested.
+type cancelDownloader struct {
+	downloader cards.ImageDownloader
+	cancel     context.CancelFunc
+	number     string
+}
+
+func (d *cancelDownloader) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResult, error) {
+	if f.Number == d.number {
+		d.cancel()
+		d.number = ""
+	}
+
+	return d.downloader.GetImage(ctx, f)
+}
+
+// changedDownloader Returns the image of another language for every conditional request to simulate a changed image.
+// brokenDownloader Returns content that is not a decodable image.
+type brokenDownloader struct{}
+
+func (d *brokenDownloader) GetImage(_ context.Context, _ cards.Filter) (*cards.ImageResult, error) {
+	return &cards.ImageResult{
+		File:     io.NopCloser(strings.NewReader("broken")),
+		MimeType: web.NewMimeType(web.MimeTypeJpeg),
+	}, nil
+}
+
+type changedDownloader struct {
+	downloader cards.ImageDownloader
+	lang       string
+}
+
+func (d *changedDownloader) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResult, error) {
+	return d.downloader.GetImage(ctx, f)
+}
+
+func (d *changedDownloader) GetImageIfModified(ctx context.Context, f cards.Filter,
+	_ cards.Validators) (*cards.ImageResult, error) {
+	f.Lang = d.lang
+
+	return d.downloader.GetImage(ctx, f)
+}
+
+func findImage(t *testing.T, cardDao *cards.PostgresCardDao, lang string) *cards.Image {
+	t.Helper()
+
+	imgs, err := cardDao.FindImages(t.Context(), nil, []string{lang})
+	require.NoError(t, err)
+	require.Len(t, imgs, 1)
+
+	return imgs[0]
 }
 
 func createCard(t *testing.T, conn *postgres.DBConnection, cc ...cards.Card) {
@@ -378,7 +1687,9 @@ func createCard(t *testing.T, conn *postgres.DBConnection, cc ...cards.Card) {
 	withDefaults := func(c *cards.Card) *cards.Card {
 		c.Rarity = "RARE"
 		c.Border = "WHITE"
-		c.Layout = "NORMAL"
+		if c.Layout == "" {
+			c.Layout = "NORMAL"
+		}
 
 		return c
 	}
@@ -0,0 +1,261 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// ImageAudit The result of comparing the card image entries with the stored files. All paths are relative to the
+// storage location.
+type ImageAudit struct {
+	Checked        int
+	MissingFiles   []string // entries without file
+	EmptyFiles     []string
+	BrokenFiles    []string // files that can't be decoded
+	MimeMismatches []string // files with a content that doesn't match the mime type of the entry
+	OrphanFiles    []string // files without entry
+	DeletedOrphans int
+	Requeued       int
+}
+
+type imageFinding int
+
+const (
+	findingNone imageFinding = iota
+	findingMissing
+	findingEmpty
+	findingBroken
+	findingMimeMismatch
+)
+
+func (a *ImageAudit) add(finding imageFinding, path string) {
+	switch finding {
+	case findingMissing:
+		a.MissingFiles = append(a.MissingFiles, path)
+	case findingEmpty:
+		a.EmptyFiles = append(a.EmptyFiles, path)
+	case findingBroken:
+		a.BrokenFiles = append(a.BrokenFiles, path)
+	case findingMimeMismatch:
+		a.MimeMismatches = append(a.MimeMismatches, path)
+	case findingNone:
+	}
+}
+
+// HasFindings Returns true if any entry or file is inconsistent.
+func (a ImageAudit) HasFindings() bool {
+	return len(a.MissingFiles) > 0 || len(a.EmptyFiles) > 0 || len(a.BrokenFiles) > 0 ||
+		len(a.MimeMismatches) > 0 || len(a.OrphanFiles) > 0
+}
+
+// ImageVerifier Cross-checks the card image entries with the files of the storage.
+type ImageVerifier interface {
+	Verify(ctx context.Context, fix bool) (ImageAudit, error)
+}
+
+type imageVerifier struct {
+	cardDao *PostgresCardDao
+	storer  storage.Storer
+	cfg     config.Images
+}
+
+func NewImageVerifier(cardDao *PostgresCardDao, storer storage.Storer, cfg config.Images) ImageVerifier {
+	return &imageVerifier{
+		cardDao: cardDao,
+		storer:  storer,
+		cfg:     cfg,
+	}
+}
+
+// Verify Reports entries with missing, empty, undecodable or mismatching files and files without entry.
+// Rendition files are not checked but count as referenced. Only the language and blob directories are checked, other
+// files of the storage e.g. downloads and symbols are ignored. With fix, orphan files are deleted and broken entries
+// are deleted together with their files, so the next image import downloads them again.
+func (v *imageVerifier) Verify(ctx context.Context, fix bool) (ImageAudit, error) {
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.SetLimit(v.cfg.WorkersOrDefault())
+
+	imgs, err := v.cardDao.FindImages(ctx, nil, nil)
+	if err != nil {
+		return ImageAudit{}, fmt.Errorf("failed to get card images %w", err)
+	}
+
+	renditions, err := v.cardDao.FindRenditions(ctx, 0)
+	if err != nil {
+		return ImageAudit{}, fmt.Errorf("failed to get card image renditions %w", err)
+	}
+
+	files := map[string]int64{}
+	err = v.storer.Walk(func(path string, size int64) error {
+		if isImageFile(path) {
+			files[path] = size
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ImageAudit{}, fmt.Errorf("failed to list stored files %w", err)
+	}
+
+	var mu sync.Mutex
+	audit := ImageAudit{Checked: len(imgs)}
+	referenced := make(map[string]bool, len(imgs)+len(renditions))
+	renditionsByImage := map[int64][]*Rendition{}
+	for _, r := range renditions {
+		referenced[filepath.ToSlash(r.ImagePath)] = true
+		renditionsByImage[r.ImageID.Get()] = append(renditionsByImage[r.ImageID.Get()], r)
+	}
+	for _, img := range imgs {
+		path := filepath.ToSlash(img.ImagePath)
+		size, exists := files[path]
+		referenced[path] = true
+
+		errg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			finding := findingMissing
+			if exists {
+				var cErr error
+				finding, cErr = v.check(img, size)
+				if cErr != nil {
+					return cErr
+				}
+			}
+			if finding == findingNone {
+				return nil
+			}
+
+			requeued := false
+			if fix {
+				err := requeueImage(ctx, v.cardDao, v.storer, img, exists, renditionsByImage[img.ID.Get()])
+				if err != nil {
+					return err
+				}
+				requeued = true
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			audit.add(finding, path)
+			if requeued {
+				audit.Requeued++
+			}
+
+			return nil
+		})
+	}
+
+	if err := errg.Wait(); err != nil {
+		return ImageAudit{}, err
+	}
+
+	for path := range files {
+		// an empty file without entry might be reserved by a running import
+		if referenced[path] || files[path] == 0 {
+			continue
+		}
+
+		audit.OrphanFiles = append(audit.OrphanFiles, path)
+		if fix {
+			if err := v.storer.Delete(path); err != nil {
+				return ImageAudit{}, fmt.Errorf("failed to delete orphan file %s %w", path, err)
+			}
+			audit.DeletedOrphans++
+		}
+	}
+
+	for _, l := range []*[]string{&audit.MissingFiles, &audit.EmptyFiles, &audit.BrokenFiles,
+		&audit.MimeMismatches, &audit.OrphanFiles} {
+		slices.Sort(*l)
+	}
+
+	return audit, nil
+}
+
+// isImageFile Returns true if the path is part of the image layout, images and renditions are stored in a directory
+// per language and blobs in the blobs directory. Temporary files of a running import are ignored.
+func isImageFile(path string) bool {
+	dir, _, found := strings.Cut(path, "/")
+	if !found || strings.HasSuffix(path, ".tmp") {
+		return false
+	}
+
+	return dir == blobsDir || slices.Contains(GetSupportedLanguages(), dir)
+}
+
+// check Returns the problem of the stored file of the image or findingNone if the file is fine.
+func (v *imageVerifier) check(img *Image, size int64) (imageFinding, error) {
+	if size == 0 {
+		return findingEmpty, nil
+	}
+
+	f, err := v.storer.Load(img.ImagePath)
+	if err != nil {
+		return findingNone, fmt.Errorf("failed to load image %s %w", img.ImagePath, err)
+	}
+	defer aio.Close(f)
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return findingNone, fmt.Errorf("failed to read image %s %w", img.ImagePath, err)
+	}
+
+	detected := web.NewMimeType(http.DetectContentType(content)).Raw()
+	if detected != web.NewMimeType(img.MimeType).Raw() {
+		log.Debug().Msgf("image %s has mime type %s but content is %s", img.ImagePath, img.MimeType, detected)
+
+		return findingMimeMismatch, nil
+	}
+
+	if _, err := DecodeImage(bytes.NewReader(content), img.MimeType); err != nil {
+		log.Debug().Err(err).Msgf("image %s can't be decoded", img.ImagePath)
+
+		return findingBroken, nil
+	}
+
+	return findingNone, nil
+}
+
+// requeueImage Deletes the entry, the file and the rendition files of the image, so the next import downloads the
+// image again. The blob of a content addressed image is only deleted once it is released by all images. The entry is
+// deleted first and is not aborted by a cancelled context, a file left behind is an orphan that verify deletes.
+func requeueImage(ctx context.Context, cardDao *PostgresCardDao, storer storage.Storer, img *Image, deleteFile bool,
+	renditions []*Rendition) error {
+	ctx = context.WithoutCancel(ctx)
+	if err := cardDao.DeleteImage(ctx, img.ID.Get()); err != nil {
+		return err
+	}
+	if err := releaseBlob(ctx, cardDao, storer, img); err != nil {
+		return err
+	}
+
+	if deleteFile && !img.BlobID.Valid {
+		if err := storer.Delete(img.ImagePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete file %s %w", img.ImagePath, err)
+		}
+	}
+	for _, r := range renditions {
+		if err := storer.Delete(r.ImagePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete rendition file %s %w", r.ImagePath, err)
+		}
+	}
+
+	return nil
+}
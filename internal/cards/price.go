@@ -0,0 +1,70 @@
+package cards
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	PriceMediumPaper  = "PAPER"
+	PriceMediumOnline = "ONLINE"
+	PriceTypeRetail   = "RETAIL"
+	PriceTypeBuylist  = "BUYLIST"
+)
+
+// CardPrices All prices of a card identified by the MTGJSON uuid of one of its faces.
+type CardPrices struct {
+	MtgjsonID string
+	Prices    []CardPrice
+}
+
+func (p *CardPrices) isValid() error {
+	if p.MtgjsonID == "" {
+		return fmt.Errorf("field 'mtgjsonId' must not be empty")
+	}
+
+	for i, price := range p.Prices {
+		if err := price.isValid(); err != nil {
+			return fmt.Errorf("price[%d] of card %s is invalid, %w", i, p.MtgjsonID, err)
+		}
+	}
+
+	return nil
+}
+
+// CardPrice The price of a card for one day.
+type CardPrice struct {
+	Date     time.Time
+	Provider string // e.g. cardmarket, tcgplayer, cardhoarder
+	Medium   string // ENUM
+	Type     string // ENUM
+	Finish   string // e.g. normal, foil, etched
+	Currency string // e.g. EUR, USD
+	Price    float64
+}
+
+func (p CardPrice) isValid() error {
+	if p.Date.IsZero() {
+		return fmt.Errorf("field 'date' must not be empty")
+	}
+	if p.Provider == "" {
+		return fmt.Errorf("field 'provider' must not be empty")
+	}
+	if p.Medium != PriceMediumPaper && p.Medium != PriceMediumOnline {
+		return fmt.Errorf("field 'medium' has unsupported value %s", p.Medium)
+	}
+	if p.Type != PriceTypeRetail && p.Type != PriceTypeBuylist {
+		return fmt.Errorf("field 'type' has unsupported value %s", p.Type)
+	}
+	if p.Finish == "" {
+		return fmt.Errorf("field 'finish' must not be empty")
+	}
+	if p.Currency == "" {
+		return fmt.Errorf("field 'currency' must not be empty")
+	}
+	if p.Price < 0 {
+		return fmt.Errorf("field 'price' must not be negative")
+	}
+
+	return nil
+}
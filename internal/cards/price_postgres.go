@@ -0,0 +1,59 @@
+package cards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+)
+
+type PostgresPriceDao struct {
+	db *postgres.DBConnection
+}
+
+func NewPriceDao(db *postgres.DBConnection) *PostgresPriceDao {
+	return &PostgresPriceDao{
+		db: db,
+	}
+}
+
+func (d *PostgresPriceDao) withTransaction(f func(txDao *PostgresPriceDao) error) error {
+	ctx := context.TODO()
+	// create a new dao instance with a transactional connection
+	return d.db.WithTransaction(ctx, func(txConn *postgres.DBConnection) error {
+		return f(NewPriceDao(txConn))
+	})
+}
+
+// AddPrice Adds the price to the history of the given card ID. Existing entries are left untouched.
+// Returns false if the entry already exists.
+func (d *PostgresPriceDao) AddPrice(cardID int64, p CardPrice) (bool, error) {
+	query := `
+		INSERT INTO
+			card_price (
+				card_id, price_date, provider, medium, price_type, finish, currency, price
+			)
+		VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+		ON CONFLICT (card_id, price_date, provider, medium, price_type, finish, currency) DO NOTHING`
+
+	ct, err := d.db.Conn.Exec(context.TODO(), query,
+		cardID, p.Date, p.Provider, p.Medium, p.Type, p.Finish, p.Currency, p.Price)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute card price insert %w", err)
+	}
+
+	return ct.RowsAffected() == 1, nil
+}
+
+// Count Returns the amount of all price entries.
+func (d *PostgresPriceDao) Count() (int, error) {
+	row := d.db.Conn.QueryRow(context.TODO(), "SELECT count(id) FROM card_price")
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute card price count %w", err)
+	}
+
+	return count, nil
+}
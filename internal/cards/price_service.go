@@ -0,0 +1,69 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+type priceService struct {
+	dao     *PostgresPriceDao
+	cardDao *PostgresCardDao
+}
+
+func NewPriceService(dao *PostgresPriceDao, cardDao *PostgresCardDao) Service[*CardPrices] {
+	return &priceService{
+		dao:     dao,
+		cardDao: cardDao,
+	}
+}
+
+// Count Counts all stored price entries.
+func (s *priceService) Count() (int, error) {
+	return s.dao.Count()
+}
+
+// Import Appends the given prices to the price history of the referenced card.
+// Prices that are already stored for the same day are skipped.
+func (s *priceService) Import(prices *CardPrices) error {
+	if prices == nil || len(prices.Prices) == 0 {
+		// Skip nil or empty prices
+		return nil
+	}
+	if err := prices.isValid(); err != nil {
+		return fmt.Errorf("card prices are invalid %w", err)
+	}
+
+	card, err := s.cardDao.FindCardByMtgjsonID(prices.MtgjsonID)
+	if err != nil {
+		if errors.Is(err, ErrEntryNotFound) {
+			if e := log.Debug(); e.Enabled() {
+				e.Msgf("Skip prices of unknown card %s", prices.MtgjsonID)
+			}
+
+			return nil
+		}
+
+		return err
+	}
+	cardID := card.ID.Get()
+
+	return s.dao.withTransaction(func(txDao *PostgresPriceDao) error {
+		added := 0
+		for _, p := range prices.Prices {
+			ok, err := txDao.AddPrice(cardID, p)
+			if err != nil {
+				return fmt.Errorf("failed to add price for card %d %w", cardID, err)
+			}
+			if ok {
+				added++
+			}
+		}
+		if e := log.Trace(); e.Enabled() {
+			e.Msgf("Added %d of %d prices for card %d", added, len(prices.Prices), cardID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package cards
+
+import (
+	"context"
+)
+
+// Symbol A mana or cost symbol e.g. {W} or {T} and its stored image.
+type Symbol struct {
+	ID        PrimaryID
+	Symbol    string // e.g. {W}
+	Name      string // english description e.g. one white mana
+	ImagePath string
+	MimeType  string
+}
+
+// SymbolSource A set icon or symbol and the url of its image.
+type SymbolSource struct {
+	Code string // set code e.g. 10E or symbol e.g. {W}
+	Name string
+	URL  string
+}
+
+// SymbolDownloader Provides the icons of all sets and the images of all mana and cost symbols.
+type SymbolDownloader interface {
+	FindSetIcons(ctx context.Context) ([]SymbolSource, error)
+	FindSymbols(ctx context.Context) ([]SymbolSource, error)
+	GetSymbolImage(ctx context.Context, url string) (*ImageResult, error)
+}
+
+type SymbolReport struct {
+	SetIcons int
+	Symbols  int
+	Skipped  int // already stored or the set doesn't exist
+	Missing  int
+}
+
+// SymbolImporter Downloads set icons and symbol images that are not stored yet.
+type SymbolImporter interface {
+	Import(ctx context.Context) (SymbolReport, error)
+}
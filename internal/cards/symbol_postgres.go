@@ -0,0 +1,71 @@
+package cards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/postgres"
+)
+
+type PostgresSymbolDao struct {
+	db *postgres.DBConnection
+}
+
+func NewSymbolDao(db *postgres.DBConnection) *PostgresSymbolDao {
+	return &PostgresSymbolDao{
+		db: db,
+	}
+}
+
+// FindSymbols Returns all symbols ordered by symbol.
+func (d *PostgresSymbolDao) FindSymbols(ctx context.Context) ([]*Symbol, error) {
+	query := `
+		SELECT
+			id, symbol, name, image_path, mime_type
+		FROM
+			card_symbol
+		ORDER BY
+			symbol`
+	rows, err := d.db.Conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select on card_symbol %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Symbol
+	for rows.Next() {
+		var s Symbol
+		if err := rows.Scan(&s.ID, &s.Symbol, &s.Name, &s.ImagePath, &s.MimeType); err != nil {
+			return nil, fmt.Errorf("failed to execute select on card_symbol %w", err)
+		}
+
+		result = append(result, &s)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to read card symbol result %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// CreateSymbol Creates a new symbol. Will return an error if the symbol already exists.
+func (d *PostgresSymbolDao) CreateSymbol(ctx context.Context, s *Symbol) error {
+	query := `
+		INSERT INTO
+			card_symbol (
+				symbol, name, image_path, mime_type
+			)
+		VALUES (
+			$1, $2, $3, $4
+		)
+		RETURNING
+			id`
+	var id int64
+	if err := d.db.Conn.QueryRow(ctx, query, s.Symbol, s.Name, s.ImagePath, s.MimeType).Scan(&id); err != nil {
+		return fmt.Errorf("failed to execute card symbol insert %w", err)
+	}
+	s.ID = NewPrimaryID(id)
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package cards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/rs/zerolog/log"
+)
+
+// SymbolsDir The storage directory of set icons and symbol images.
+const SymbolsDir = "symbols"
+
+const (
+	setIconsDir = "sets"
+	costDir     = "cost"
+)
+
+type symbolImporter struct {
+	setDao     *PostgresSetDao
+	symbolDao  *PostgresSymbolDao
+	storer     storage.Storer
+	downloader SymbolDownloader
+}
+
+func NewSymbolImporter(setDao *PostgresSetDao, symbolDao *PostgresSymbolDao, storer storage.Storer,
+	downloader SymbolDownloader) SymbolImporter {
+	return &symbolImporter{
+		setDao:     setDao,
+		symbolDao:  symbolDao,
+		storer:     storer,
+		downloader: downloader,
+	}
+}
+
+// Import Downloads the icons of all existing sets and the images of all symbols that are not stored yet. Icons that
+// are shared by multiple sets are stored once.
+func (s *symbolImporter) Import(ctx context.Context) (SymbolReport, error) {
+	report := SymbolReport{}
+	if err := s.importSetIcons(ctx, &report); err != nil {
+		return SymbolReport{}, err
+	}
+	if err := s.importSymbols(ctx, &report); err != nil {
+		return SymbolReport{}, err
+	}
+
+	return report, nil
+}
+
+func (s *symbolImporter) importSetIcons(ctx context.Context, report *SymbolReport) error {
+	icons, err := s.downloader.FindSetIcons(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get set icons %w", err)
+	}
+
+	existing, err := s.setDao.FindSetIcons(ctx)
+	if err != nil {
+		return err
+	}
+
+	stored := map[string]string{}
+	for _, icon := range icons {
+		current, ok := existing[icon.Code]
+		if !ok || current != "" || icon.URL == "" {
+			report.Skipped++
+
+			continue
+		}
+
+		iconPath, ok := stored[icon.URL]
+		if !ok {
+			iconPath, _, err = s.store(ctx, icon.URL, setIconsDir)
+			if err != nil {
+				if errors.Is(err, ErrImageNotFound) {
+					log.Warn().Err(err).Msgf("icon of set %s not found", icon.Code)
+					report.Missing++
+
+					continue
+				}
+
+				return err
+			}
+			stored[icon.URL] = iconPath
+		}
+
+		if err := s.setDao.UpdateSetIcon(ctx, icon.Code, iconPath); err != nil {
+			return err
+		}
+		report.SetIcons++
+	}
+
+	return nil
+}
+
+func (s *symbolImporter) importSymbols(ctx context.Context, report *SymbolReport) error {
+	sources, err := s.downloader.FindSymbols(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get symbols %w", err)
+	}
+
+	existing, err := s.symbolDao.FindSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, sym := range existing {
+		known[sym.Symbol] = true
+	}
+
+	for _, src := range sources {
+		if known[src.Code] || src.URL == "" {
+			report.Skipped++
+
+			continue
+		}
+
+		imagePath, mimeType, err := s.store(ctx, src.URL, costDir)
+		if err != nil {
+			if errors.Is(err, ErrImageNotFound) {
+				log.Warn().Err(err).Msgf("image of symbol %s not found", src.Code)
+				report.Missing++
+
+				continue
+			}
+
+			return err
+		}
+
+		sym := &Symbol{Symbol: src.Code, Name: src.Name, ImagePath: imagePath, MimeType: mimeType}
+		if err := s.symbolDao.CreateSymbol(ctx, sym); err != nil {
+			return err
+		}
+		known[src.Code] = true
+		report.Symbols++
+	}
+
+	return nil
+}
+
+// store Downloads the image and stores it in the given directory, named after the last element of the url path.
+// An already stored file is not downloaded again, its mime type is derived from the extension of the url path.
+func (s *symbolImporter) store(ctx context.Context, rawURL, dir string) (string, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid symbol url %s %w", rawURL, err)
+	}
+	baseName := strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+	if baseName == "" || baseName == "." || baseName == "/" {
+		return "", "", fmt.Errorf("symbol url %s has no file name", rawURL)
+	}
+
+	mimeType := web.NewMimeType(mime.TypeByExtension(path.Ext(u.Path)))
+	if fileName, err := mimeType.BuildFilename(baseName); err == nil {
+		if filePath, ok := s.stored(dir, fileName); ok {
+			return filePath, mimeType.Raw(), nil
+		}
+	}
+
+	result, err := s.downloader.GetSymbolImage(ctx, rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download symbol %s %w", rawURL, err)
+	}
+	defer aio.Close(result.File)
+
+	fileName, err := result.MimeType.BuildFilename(baseName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build filename of symbol %s %w", rawURL, err)
+	}
+
+	// the downloaded mime type might not match the extension of the url
+	if filePath, ok := s.stored(dir, fileName); ok {
+		return filePath, result.MimeType.Raw(), nil
+	}
+
+	storedFile, err := s.storer.Store(result.File, SymbolsDir, dir, fileName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store symbol %s %w", rawURL, err)
+	}
+
+	return storedFile.Path, result.MimeType.Raw(), nil
+}
+
+// stored Returns the storage path of the file in the given directory if the file exists.
+func (s *symbolImporter) stored(dir, fileName string) (string, bool) {
+	f, err := s.storer.Load(SymbolsDir, dir, fileName)
+	if err != nil {
+		return "", false
+	}
+	aio.Close(f)
+
+	return path.Join(SymbolsDir, dir, fileName), true
+}
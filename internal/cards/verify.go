@@ -0,0 +1,212 @@
+package cards
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+const (
+	MissingInDatabase = "database"
+	MissingInSource   = "source"
+)
+
+// CardKey Identifies a card by set code and number.
+type CardKey struct {
+	CardSetCode string
+	Number      string
+}
+
+func (k CardKey) String() string {
+	return fmt.Sprintf("%s-%s", k.CardSetCode, k.Number)
+}
+
+func compareKeys(a, b CardKey) int {
+	return cmp.Or(cmp.Compare(a.CardSetCode, b.CardSetCode), cmp.Compare(a.Number, b.Number))
+}
+
+// KeyMismatch A card that exists in both sources but with a different set code or number.
+type KeyMismatch struct {
+	Name     string
+	Database CardKey
+	Source   CardKey
+}
+
+// MissingTranslation A translation of a card face that only exists in one source.
+type MissingTranslation struct {
+	Card      CardKey
+	Face      string
+	Lang      string
+	MissingIn string // database or source
+}
+
+// VerifyReport The differences between the cards of the database and the cards of another source.
+// Changes contain the database value as 'from' and the source value as 'to'.
+type VerifyReport struct {
+	MissingInDatabase   []CardKey
+	MissingInSource     []CardKey
+	KeyMismatches       []KeyMismatch
+	Changes             map[CardKey]*Changeset
+	MissingTranslations []MissingTranslation
+}
+
+func (r *VerifyReport) HasFindings() bool {
+	return len(r.MissingInDatabase) > 0 || len(r.MissingInSource) > 0 || len(r.KeyMismatches) > 0 ||
+		len(r.Changes) > 0 || len(r.MissingTranslations) > 0
+}
+
+// Verify Compares the cards of the database with the cards of another source. Cards are matched by set code and
+// number, unmatched cards with the same name and either the same set code or the same number are reported as
+// key mismatch. Translations are only compared if compareTranslations is true, because not every source
+// provides translations.
+func Verify(database []*Card, source []*Card, compareTranslations bool) *VerifyReport {
+	report := &VerifyReport{Changes: map[CardKey]*Changeset{}}
+
+	sourceByKey := make(map[CardKey]*Card, len(source))
+	for _, c := range source {
+		sourceByKey[CardKey{CardSetCode: c.CardSetCode, Number: c.Number}] = c
+	}
+
+	var dbOnly []*Card
+	matched := map[CardKey]bool{}
+	for _, dbCard := range database {
+		key := CardKey{CardSetCode: dbCard.CardSetCode, Number: dbCard.Number}
+		srcCard, ok := sourceByKey[key]
+		if !ok {
+			dbOnly = append(dbOnly, dbCard)
+
+			continue
+		}
+		matched[key] = true
+
+		if diff := diffForVerify(dbCard, srcCard); diff.HasChanges() {
+			report.Changes[key] = diff
+		}
+		if compareTranslations {
+			report.MissingTranslations = append(report.MissingTranslations, missingTranslations(key, dbCard, srcCard)...)
+		}
+	}
+
+	var srcOnly []*Card
+	srcOnlyByName := map[string][]*Card{}
+	for _, c := range source {
+		if !matched[CardKey{CardSetCode: c.CardSetCode, Number: c.Number}] {
+			srcOnly = append(srcOnly, c)
+			srcOnlyByName[c.Name] = append(srcOnlyByName[c.Name], c)
+		}
+	}
+
+	mismatched := map[*Card]bool{}
+	for _, dbCard := range dbOnly {
+		dbKey := CardKey{CardSetCode: dbCard.CardSetCode, Number: dbCard.Number}
+		candidates := srcOnlyByName[dbCard.Name]
+		pos := slices.IndexFunc(candidates, func(c *Card) bool {
+			return c.CardSetCode == dbCard.CardSetCode || c.Number == dbCard.Number
+		})
+		if pos == -1 {
+			report.MissingInSource = append(report.MissingInSource, dbKey)
+
+			continue
+		}
+
+		srcCard := candidates[pos]
+		srcOnlyByName[dbCard.Name] = slices.Delete(candidates, pos, pos+1)
+		mismatched[srcCard] = true
+		report.KeyMismatches = append(report.KeyMismatches, KeyMismatch{
+			Name:     dbCard.Name,
+			Database: dbKey,
+			Source:   CardKey{CardSetCode: srcCard.CardSetCode, Number: srcCard.Number},
+		})
+	}
+	for _, c := range srcOnly {
+		if !mismatched[c] {
+			report.MissingInDatabase = append(report.MissingInDatabase, CardKey{CardSetCode: c.CardSetCode, Number: c.Number})
+		}
+	}
+
+	slices.SortFunc(report.MissingInSource, compareKeys)
+	slices.SortFunc(report.MissingInDatabase, compareKeys)
+	slices.SortFunc(report.KeyMismatches, func(a, b KeyMismatch) int {
+		return compareKeys(a.Database, b.Database)
+	})
+	slices.SortFunc(report.MissingTranslations, func(a, b MissingTranslation) int {
+		return cmp.Or(compareKeys(a.Card, b.Card), cmp.Compare(a.Face, b.Face), cmp.Compare(a.Lang, b.Lang))
+	})
+
+	return report
+}
+
+// diffForVerify Compares the card name and the name, mana cost and type line of each face.
+func diffForVerify(dbCard *Card, srcCard *Card) *Changeset {
+	changes := NewDiff()
+
+	if dbCard.Name != srcCard.Name {
+		changes.Add("Name", Changes{
+			From: dbCard.Name,
+			To:   srcCard.Name,
+		})
+	}
+
+	var dbFaces, srcFaces []string
+	for _, dbFace := range dbCard.Faces {
+		pos := slices.IndexFunc(srcCard.Faces, func(f *Face) bool { return f.Name == dbFace.Name })
+		if pos == -1 {
+			dbFaces = append(dbFaces, dbFace.Name)
+
+			continue
+		}
+
+		srcFace := srcCard.Faces[pos]
+		if dbFace.ManaCost != srcFace.ManaCost {
+			changes.Add(fmt.Sprintf("Face[%s].ManaCost", dbFace.Name), Changes{
+				From: dbFace.ManaCost,
+				To:   srcFace.ManaCost,
+			})
+		}
+		if dbFace.TypeLine != srcFace.TypeLine {
+			changes.Add(fmt.Sprintf("Face[%s].TypeLine", dbFace.Name), Changes{
+				From: dbFace.TypeLine,
+				To:   srcFace.TypeLine,
+			})
+		}
+	}
+	for _, srcFace := range srcCard.Faces {
+		if !slices.ContainsFunc(dbCard.Faces, func(f *Face) bool { return f.Name == srcFace.Name }) {
+			srcFaces = append(srcFaces, srcFace.Name)
+		}
+	}
+	if len(dbFaces) > 0 || len(srcFaces) > 0 {
+		changes.Add("Faces", Changes{
+			From: dbFaces,
+			To:   srcFaces,
+		})
+	}
+
+	return changes
+}
+
+func missingTranslations(key CardKey, dbCard *Card, srcCard *Card) []MissingTranslation {
+	var result []MissingTranslation
+	for _, dbFace := range dbCard.Faces {
+		pos := slices.IndexFunc(srcCard.Faces, func(f *Face) bool { return f.Name == dbFace.Name })
+		if pos == -1 {
+			continue
+		}
+		srcFace := srcCard.Faces[pos]
+
+		for _, t := range dbFace.Translations {
+			if ok, _ := containsFaceTranslation(srcFace.Translations, t); !ok {
+				result = append(result, MissingTranslation{Card: key, Face: dbFace.Name, Lang: t.Lang,
+					MissingIn: MissingInSource})
+			}
+		}
+		for _, t := range srcFace.Translations {
+			if ok, _ := containsFaceTranslation(dbFace.Translations, t); !ok {
+				result = append(result, MissingTranslation{Card: key, Face: dbFace.Name, Lang: t.Lang,
+					MissingIn: MissingInDatabase})
+			}
+		}
+	}
+
+	return result
+}
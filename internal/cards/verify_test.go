@@ -0,0 +1,97 @@
+package cards_test
+
+import (
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	database := []*cards.Card{
+		{CardSetCode: "10E", Number: "1", Name: "Same", Faces: []*cards.Face{{Name: "Same", ManaCost: "{W}"}}},
+		{
+			CardSetCode: "10E", Number: "2", Name: "Changed",
+			Faces: []*cards.Face{{Name: "Changed", ManaCost: "{W}", TypeLine: "Creature — Human"}},
+		},
+		{CardSetCode: "10E", Number: "3", Name: "Only Database", Faces: []*cards.Face{{Name: "Only Database"}}},
+		{CardSetCode: "10E", Number: "4", Name: "Moved", Faces: []*cards.Face{{Name: "Moved"}}},
+		{CardSetCode: "PRM", Number: "5", Name: "Other Set", Faces: []*cards.Face{{Name: "Other Set"}}},
+	}
+	source := []*cards.Card{
+		{CardSetCode: "10E", Number: "1", Name: "Same", Faces: []*cards.Face{{Name: "Same", ManaCost: "{W}"}}},
+		{
+			CardSetCode: "10E", Number: "2", Name: "Changed",
+			Faces: []*cards.Face{{Name: "Changed", ManaCost: "{1}{W}", TypeLine: "Creature — Human Soldier"}},
+		},
+		{CardSetCode: "10E", Number: "40", Name: "Moved", Faces: []*cards.Face{{Name: "Moved"}}},
+		{CardSetCode: "PMEI", Number: "5", Name: "Other Set", Faces: []*cards.Face{{Name: "Other Set"}}},
+		{CardSetCode: "NEW", Number: "1", Name: "Only Source", Faces: []*cards.Face{{Name: "Only Source"}}},
+	}
+
+	report := cards.Verify(database, source, false)
+
+	assert.True(t, report.HasFindings())
+	assert.Equal(t, []cards.CardKey{{CardSetCode: "10E", Number: "3"}}, report.MissingInSource)
+	assert.Equal(t, []cards.CardKey{{CardSetCode: "NEW", Number: "1"}}, report.MissingInDatabase)
+	assert.Equal(t, []cards.KeyMismatch{
+		{
+			Name:     "Moved",
+			Database: cards.CardKey{CardSetCode: "10E", Number: "4"},
+			Source:   cards.CardKey{CardSetCode: "10E", Number: "40"},
+		},
+		{
+			Name:     "Other Set",
+			Database: cards.CardKey{CardSetCode: "PRM", Number: "5"},
+			Source:   cards.CardKey{CardSetCode: "PMEI", Number: "5"},
+		},
+	}, report.KeyMismatches)
+	assert.Len(t, report.Changes, 1)
+	changes := report.Changes[cards.CardKey{CardSetCode: "10E", Number: "2"}]
+	assert.Contains(t, changes.String(), "Field 'Face[Changed].ManaCost' from '{W}' to '{1}{W}'")
+	assert.Contains(t, changes.String(),
+		"Field 'Face[Changed].TypeLine' from 'Creature — Human' to 'Creature — Human Soldier'")
+	assert.Empty(t, report.MissingTranslations)
+}
+
+func TestVerifyFaces(t *testing.T) {
+	database := []*cards.Card{
+		{CardSetCode: "10E", Number: "1", Name: "A // B", Faces: []*cards.Face{{Name: "A"}, {Name: "B"}}},
+	}
+	source := []*cards.Card{
+		{CardSetCode: "10E", Number: "1", Name: "A // C", Faces: []*cards.Face{{Name: "A"}, {Name: "C"}}},
+	}
+
+	report := cards.Verify(database, source, false)
+
+	changes := report.Changes[cards.CardKey{CardSetCode: "10E", Number: "1"}]
+	assert.Contains(t, changes.String(), "Field 'Name' from 'A // B' to 'A // C'")
+	assert.Contains(t, changes.String(), "Field 'Faces' from '[B]' to '[C]'")
+}
+
+func TestVerifyTranslations(t *testing.T) {
+	database := []*cards.Card{
+		{
+			CardSetCode: "10E", Number: "1", Name: "A",
+			Faces: []*cards.Face{{Name: "A", Translations: []cards.FaceTranslation{{Name: "A de", Lang: "deu"}}}},
+		},
+		{CardSetCode: "10E", Number: "2", Name: "B", Faces: []*cards.Face{{Name: "B"}}},
+	}
+	source := []*cards.Card{
+		{CardSetCode: "10E", Number: "1", Name: "A", Faces: []*cards.Face{{Name: "A"}}},
+		{
+			CardSetCode: "10E", Number: "2", Name: "B",
+			Faces: []*cards.Face{{Name: "B", Translations: []cards.FaceTranslation{{Name: "B de", Lang: "deu"}}}},
+		},
+	}
+	want := []cards.MissingTranslation{
+		{Card: cards.CardKey{CardSetCode: "10E", Number: "1"}, Face: "A", Lang: "deu", MissingIn: cards.MissingInSource},
+		{Card: cards.CardKey{CardSetCode: "10E", Number: "2"}, Face: "B", Lang: "deu", MissingIn: cards.MissingInDatabase},
+	}
+
+	withoutTranslations := cards.Verify(database, source, false)
+	withTranslations := cards.Verify(database, source, true)
+
+	assert.False(t, withoutTranslations.HasFindings())
+	assert.Equal(t, want, withTranslations.MissingTranslations)
+}
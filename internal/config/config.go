@@ -9,7 +9,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
 	"go.yaml.in/yaml/v3"
@@ -21,7 +23,9 @@ var (
 )
 
 type Config struct {
+	Dataset  Dataset  `yaml:"dataset"`
 	Storage  Storage  `yaml:"storage"`
+	Images   Images   `yaml:"images"`
 	Logging  Logging  `yaml:"logging"`
 	Mtgjson  Mtgjson  `yaml:"mtgjson"`
 	Scryfall Scryfall `yaml:"scryfall"`
@@ -59,9 +63,58 @@ func (d Database) MaxConnectionsOrDefault() int32 {
 	return d.MaxConnections
 }
 
+const (
+	SourceMtgjson  = "mtgjson"
+	SourceScryfall = "scryfall"
+)
+
+type Dataset struct {
+	Source string `yaml:"source"`
+}
+
+// SourceOrDefault Returns the configured dataset source or mtgjson if not set.
+func (d Dataset) SourceOrDefault() string {
+	source := strings.ToLower(strings.TrimSpace(d.Source))
+	if source == "" {
+		return SourceMtgjson
+	}
+
+	return source
+}
+
 type Scryfall struct {
-	BaseURL string     `yaml:"baseUrl"`
-	Client  web.Config `yaml:"client"`
+	BaseURL    string     `yaml:"baseUrl"`
+	BulkType   string     `yaml:"bulkType"`
+	ImageIndex ImageIndex `yaml:"imageIndex"`
+	Client     web.Config `yaml:"client"`
+}
+
+// ImageIndex A bulk data file that is used to look up image urls instead of requesting every card.
+type ImageIndex struct {
+	Enabled  bool   `yaml:"enabled"`
+	File     string `yaml:"file"`     // local bulk data file, the file is downloaded if not set
+	BulkType string `yaml:"bulkType"` // bulk data type that is downloaded
+}
+
+// BulkTypeOrDefault Returns the configured bulk data type or all_cards if not set.
+// All cards is the only bulk data type that contains every language of a card.
+func (i ImageIndex) BulkTypeOrDefault() string {
+	bulkType := strings.TrimSpace(i.BulkType)
+	if bulkType == "" {
+		return "all_cards"
+	}
+
+	return bulkType
+}
+
+// BulkTypeOrDefault Returns the configured bulk data type or default_cards if not set.
+func (s Scryfall) BulkTypeOrDefault() string {
+	bulkType := strings.TrimSpace(s.BulkType)
+	if bulkType == "" {
+		return "default_cards"
+	}
+
+	return bulkType
 }
 
 func (s Scryfall) EnsureBaseURL(urlPath string) (string, error) {
@@ -81,6 +134,8 @@ func (s Scryfall) EnsureBaseURL(urlPath string) (string, error) {
 
 type Mtgjson struct {
 	DatasetURL string     `yaml:"datasetUrl"`
+	PricesURL  string     `yaml:"pricesUrl"`
+	DecksURL   string     `yaml:"decksUrl"`
 	Client     web.Config `yaml:"client"`
 }
 
@@ -107,6 +162,150 @@ type Storage struct {
 	Mode     string `yaml:"mode"`
 }
 
+type Images struct {
+	Workers            int      `yaml:"workers"`
+	Variants           []string `yaml:"variants"`           // e.g. small, normal, large, png, art_crop, border_crop
+	AverageHashSize    int      `yaml:"averageHashSize"`    // width and height up to 64, size*size bits, max 4096 bits
+	DifferenceHashSize int      `yaml:"differenceHashSize"` // width and height up to 64, size*size bits, max 4096 bits
+	Renditions         []int    `yaml:"renditions"`         // widths of the generated jpeg renditions
+	GrayscaleWidth     int      `yaml:"grayscaleWidth"`     // width of the grayscale rendition, 0 disables it
+	// RetryBackoff wait time before a missing image is requested again, doubled with every failed attempt.
+	RetryBackoff    time.Duration `yaml:"retryBackoff"`
+	MaxRetryBackoff time.Duration `yaml:"maxRetryBackoff"`
+	// Sources image providers in priority order, the next provider is requested if an image is not found.
+	Sources []string     `yaml:"sources"`
+	Archive ImageArchive `yaml:"archive"`
+	// ContentAddressed stores images named by the sha256 of their content, images with the same content share a file.
+	ContentAddressed bool    `yaml:"contentAddressed"`
+	QA               ImageQA `yaml:"qa"`
+}
+
+// ImageQA Thresholds of the image quality report.
+type ImageQA struct {
+	// MaxDistance hamming distance of the perception hashes up to which two images count as near-identical.
+	MaxDistance int `yaml:"maxDistance"`
+	// Placeholders perception hashes of known placeholder images e.g. the card back, as 64 hex characters.
+	Placeholders []string `yaml:"placeholders"`
+}
+
+// MaxDistanceOrDefault Returns the configured maximum distance or 4 if not set.
+func (q ImageQA) MaxDistanceOrDefault() int {
+	if q.MaxDistance <= 0 {
+		return 4
+	}
+
+	return q.MaxDistance
+}
+
+const SourceArchive = "archive"
+
+// SourcesOrDefault Returns the configured image sources in priority order or scryfall if not set.
+func (i Images) SourcesOrDefault() []string {
+	var sources []string
+	for _, s := range i.Sources {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s != "" && !slices.Contains(sources, s) {
+			sources = append(sources, s)
+		}
+	}
+	if len(sources) == 0 {
+		return []string{SourceScryfall}
+	}
+
+	return sources
+}
+
+// ImageArchive A local directory or zip file with card images e.g. a licensed scan archive.
+type ImageArchive struct {
+	Path string `yaml:"path"` // directory or zip file
+	// Template path of an image inside the archive with the placeholders {set}, {number}, {lang}, {name} and {variant}.
+	Template string `yaml:"template"`
+}
+
+// TemplateOrDefault Returns the configured path template or {set}/{number}_{lang}.jpg if not set.
+func (a ImageArchive) TemplateOrDefault() string {
+	tmpl := strings.TrimSpace(a.Template)
+	if tmpl == "" {
+		return "{set}/{number}_{lang}.jpg"
+	}
+
+	return tmpl
+}
+
+// RetryBackoffOrDefault Returns the configured retry backoff or 24 hours if not set.
+func (i Images) RetryBackoffOrDefault() time.Duration {
+	if i.RetryBackoff <= 0 {
+		return 24 * time.Hour
+	}
+
+	return i.RetryBackoff
+}
+
+// MaxRetryBackoffOrDefault Returns the configured maximum retry backoff or 30 days if not set.
+func (i Images) MaxRetryBackoffOrDefault() time.Duration {
+	if i.MaxRetryBackoff <= 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return i.MaxRetryBackoff
+}
+
+// RenditionWidths Returns the configured positive rendition widths in ascending order without duplicates.
+func (i Images) RenditionWidths() []int {
+	var widths []int
+	for _, w := range i.Renditions {
+		if w > 0 && !slices.Contains(widths, w) {
+			widths = append(widths, w)
+		}
+	}
+	slices.Sort(widths)
+
+	return widths
+}
+
+// AverageHashSizeOrDefault Returns the configured average hash size or 16 if not set.
+func (i Images) AverageHashSizeOrDefault() int {
+	if i.AverageHashSize <= 0 {
+		return 16
+	}
+
+	return i.AverageHashSize
+}
+
+// DifferenceHashSizeOrDefault Returns the configured difference hash size or 16 if not set.
+func (i Images) DifferenceHashSizeOrDefault() int {
+	if i.DifferenceHashSize <= 0 {
+		return 16
+	}
+
+	return i.DifferenceHashSize
+}
+
+// VariantsOrDefault Returns the configured image variants or normal if not set.
+func (i Images) VariantsOrDefault() []string {
+	var variants []string
+	for _, v := range i.Variants {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" && !slices.Contains(variants, v) {
+			variants = append(variants, v)
+		}
+	}
+	if len(variants) == 0 {
+		return []string{"normal"}
+	}
+
+	return variants
+}
+
+// WorkersOrDefault Returns the configured amount of parallel image imports or 1 if not set.
+func (i Images) WorkersOrDefault() int {
+	if i.Workers <= 0 {
+		return 1
+	}
+
+	return i.Workers
+}
+
 func ReadConfigs(path ...string) (Config, error) {
 	cfg := Config{}
 
@@ -127,3 +127,64 @@ func TestEnsureBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestDatasetSourceOrDefault(t *testing.T) {
+	assert.Equal(t, config.SourceMtgjson, config.Dataset{}.SourceOrDefault())
+	assert.Equal(t, config.SourceScryfall, config.Dataset{Source: " Scryfall "}.SourceOrDefault())
+}
+
+func TestScryfallBulkTypeOrDefault(t *testing.T) {
+	assert.Equal(t, "default_cards", config.Scryfall{}.BulkTypeOrDefault())
+	assert.Equal(t, "all_cards", config.Scryfall{BulkType: "all_cards"}.BulkTypeOrDefault())
+}
+
+func TestImageIndexBulkTypeOrDefault(t *testing.T) {
+	assert.Equal(t, "all_cards", config.ImageIndex{}.BulkTypeOrDefault())
+	assert.Equal(t, "default_cards", config.ImageIndex{BulkType: "default_cards"}.BulkTypeOrDefault())
+}
+
+func TestImagesWorkersOrDefault(t *testing.T) {
+	assert.Equal(t, 1, config.Images{}.WorkersOrDefault())
+	assert.Equal(t, 1, config.Images{Workers: -1}.WorkersOrDefault())
+	assert.Equal(t, 8, config.Images{Workers: 8}.WorkersOrDefault())
+}
+
+func TestImagesVariantsOrDefault(t *testing.T) {
+	assert.Equal(t, []string{"normal"}, config.Images{}.VariantsOrDefault())
+	assert.Equal(t, []string{"small", "art_crop"}, config.Images{Variants: []string{" Small", "art_crop", "small", ""}}.VariantsOrDefault())
+}
+
+func TestImagesHashSizeOrDefault(t *testing.T) {
+	assert.Equal(t, 16, config.Images{}.AverageHashSizeOrDefault())
+	assert.Equal(t, 8, config.Images{AverageHashSize: 8}.AverageHashSizeOrDefault())
+	assert.Equal(t, 16, config.Images{}.DifferenceHashSizeOrDefault())
+	assert.Equal(t, 32, config.Images{DifferenceHashSize: 32}.DifferenceHashSizeOrDefault())
+}
+
+func TestImagesRenditionWidths(t *testing.T) {
+	assert.Empty(t, config.Images{}.RenditionWidths())
+	assert.Equal(t, []int{146, 488}, config.Images{Renditions: []int{488, 0, 146, -1, 488}}.RenditionWidths())
+}
+
+func TestImagesRetryBackoffOrDefault(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, config.Images{}.RetryBackoffOrDefault())
+	assert.Equal(t, time.Hour, config.Images{RetryBackoff: time.Hour}.RetryBackoffOrDefault())
+	assert.Equal(t, 30*24*time.Hour, config.Images{}.MaxRetryBackoffOrDefault())
+	assert.Equal(t, 48*time.Hour, config.Images{MaxRetryBackoff: 48 * time.Hour}.MaxRetryBackoffOrDefault())
+}
+
+func TestImagesSourcesOrDefault(t *testing.T) {
+	assert.Equal(t, []string{config.SourceScryfall}, config.Images{}.SourcesOrDefault())
+	assert.Equal(t, []string{config.SourceArchive, config.SourceScryfall},
+		config.Images{Sources: []string{" Archive", "scryfall", "archive", ""}}.SourcesOrDefault())
+}
+
+func TestImageArchiveTemplateOrDefault(t *testing.T) {
+	assert.Equal(t, "{set}/{number}_{lang}.jpg", config.ImageArchive{}.TemplateOrDefault())
+	assert.Equal(t, "{lang}/{name}.png", config.ImageArchive{Template: "{lang}/{name}.png"}.TemplateOrDefault())
+}
+
+func TestImageQAMaxDistanceOrDefault(t *testing.T) {
+	assert.Equal(t, 4, config.ImageQA{}.MaxDistanceOrDefault())
+	assert.Equal(t, 8, config.ImageQA{MaxDistance: 8}.MaxDistanceOrDefault())
+}
@@ -97,6 +97,8 @@ func parseSet(ctx context.Context, dec *json.Decoder, c chan<- result) error {
 	var totalCount float64
 	var released string
 	var translations []translation
+	var boosters map[string]booster
+	var sealedProducts []sealedProduct
 
 	for dec.More() {
 		select {
@@ -165,6 +167,14 @@ func parseSet(ctx context.Context, dec *json.Decoder, c chan<- result) error {
 			if err := parseCard(ctx, c, dec); err != nil {
 				return err
 			}
+		case "booster":
+			if err := dec.Decode(&boosters); err != nil {
+				return fmt.Errorf("failed to decode field booster %w", err)
+			}
+		case "sealedProduct":
+			if err := dec.Decode(&sealedProducts); err != nil {
+				return fmt.Errorf("failed to decode field sealedProduct %w", err)
+			}
 		default:
 			if err := skip(dec); err != nil {
 				return err
@@ -181,13 +191,15 @@ func parseSet(ctx context.Context, dec *json.Decoder, c chan<- result) error {
 	}
 
 	c <- result{Result: mtgjsonCardSet{
-		Code:         code,
-		Name:         name,
-		Block:        block,
-		Type:         setType,
-		TotalCount:   totalCount,
-		Released:     released,
-		Translations: translations,
+		Code:           code,
+		Name:           name,
+		Block:          block,
+		Type:           setType,
+		TotalCount:     totalCount,
+		Released:       released,
+		Translations:   translations,
+		Boosters:       boosters,
+		SealedProducts: sealedProducts,
 	}, Err: nil}
 
 	return expectNext(json.Delim('}'), dec)
@@ -111,6 +111,49 @@ func TestParseSet(t *testing.T) {
 			`),
 			want: []mtgjsonCardSet{{Code: "10E", Translations: nil}},
 		},
+		{
+			name: "FindSetWithBoostersAndSealedProducts",
+			source: strings.NewReader(`
+				{
+					"data": {
+						"10E": {
+							"code": "10E",
+							"booster": {
+								"draft": {
+									"name": "Draft Booster",
+									"boosters": [{"contents": {"common": 1}, "weight": 2}],
+									"boostersTotalWeight": 2,
+									"sheets": {
+										"common": {"cards": {"100": 1}, "foil": true, "totalWeight": 1}
+									}
+								}
+							},
+							"sealedProduct": [
+								{"uuid": "910", "name": "Booster Box", "category": "booster_box", "contents": {"sealed": []}}
+							]
+						}
+					}
+				}
+			`),
+			want: []mtgjsonCardSet{
+				{
+					Code: "10E",
+					Boosters: map[string]booster{
+						"draft": {
+							Name:                "Draft Booster",
+							Boosters:            []boosterVariant{{Contents: map[string]int{"common": 1}, Weight: 2}},
+							BoostersTotalWeight: 2,
+							Sheets: map[string]boosterSheet{
+								"common": {Cards: map[string]int{"100": 1}, Foil: true, TotalWeight: 1},
+							},
+						},
+					},
+					SealedProducts: []sealedProduct{
+						{UUID: "910", Name: "Booster Box", Category: "booster_box", Contents: []byte(`{"sealed": []}`)},
+					},
+				},
+			},
+		},
 	}
 
 	for i := range cases {
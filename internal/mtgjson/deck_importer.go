@@ -0,0 +1,81 @@
+package mtgjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+)
+
+type mtgJSONDecks struct {
+	deckService cards.Service[*cards.Deck]
+}
+
+// NewDeckImporter Creates a dataset that imports a single MTGJSON deck file.
+func NewDeckImporter(deckService cards.Service[*cards.Deck]) cards.Dataset {
+	return &mtgJSONDecks{
+		deckService: deckService,
+	}
+}
+
+func (imp *mtgJSONDecks) Import(r io.Reader) (*cards.Report, error) {
+	var file mtgjsonDeckFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode deck file %w", err)
+	}
+	if file.Data == nil {
+		return nil, fmt.Errorf("deck file contains no data")
+	}
+
+	if err := imp.deckService.Import(mapToDeck(*file.Data)); err != nil {
+		return nil, err
+	}
+
+	deckCount, err := imp.deckService.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cards.Report{
+		DeckCount: deckCount,
+	}, nil
+}
+
+func mapToDeck(d mtgjsonDeck) *cards.Deck {
+	released, err := time.Parse("2006-01-02", strings.TrimSpace(d.ReleaseDate)) // ISO 8601 YYYY-MM-DD
+	if err != nil {
+		released = time.Time{}
+	}
+
+	var entries []cards.DeckEntry
+	entries = append(entries, mapToDeckEntries(d.Commander, cards.DeckBoardCommander)...)
+	entries = append(entries, mapToDeckEntries(d.MainBoard, cards.DeckBoardMain)...)
+	entries = append(entries, mapToDeckEntries(d.SideBoard, cards.DeckBoardSide)...)
+
+	return &cards.Deck{
+		CardSetCode: strings.TrimSpace(d.Code),
+		Name:        strings.TrimSpace(d.Name),
+		Type:        strings.TrimSpace(d.Type),
+		Released:    released,
+		Entries:     entries,
+	}
+}
+
+func mapToDeckEntries(entries []mtgjsonDeckEntry, board string) []cards.DeckEntry {
+	result := make([]cards.DeckEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, cards.DeckEntry{
+			Board:       board,
+			Count:       e.Count,
+			Foil:        e.Foil,
+			MtgjsonID:   strings.TrimSpace(e.UUID),
+			CardSetCode: strings.TrimSpace(e.Code),
+			Number:      strings.TrimSpace(e.Number),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,59 @@
+package mtgjson_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/mtgjson"
+	"github.com/konstantinfoerster/card-importer-go/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockDeckService struct {
+	Decks []cards.Deck
+}
+
+func (s *MockDeckService) Import(deck *cards.Deck) error {
+	s.Decks = append(s.Decks, *deck)
+
+	return nil
+}
+
+func (s *MockDeckService) Count() (int, error) {
+	return len(s.Decks), nil
+}
+
+func TestImportDeck(t *testing.T) {
+	deckService := &MockDeckService{}
+	importer := mtgjson.NewDeckImporter(deckService)
+	want := cards.Deck{
+		CardSetCode: "2ED",
+		Name:        "Commander Deck",
+		Type:        "Commander Deck",
+		Released:    time.Date(2018, time.August, 10, 0, 0, 0, 0, time.UTC),
+		Entries: []cards.DeckEntry{
+			{Board: cards.DeckBoardCommander, Count: 1, Foil: true, CardSetCode: "2ED", Number: "4"},
+			{Board: cards.DeckBoardMain, Count: 2, MtgjsonID: "100", CardSetCode: "2ED", Number: "1"},
+			{Board: cards.DeckBoardMain, Count: 1, MtgjsonID: "999", CardSetCode: "2ED", Number: "999"},
+			{Board: cards.DeckBoardSide, Count: 3, MtgjsonID: "200", CardSetCode: "2ED", Number: "3"},
+		},
+	}
+
+	report, err := importer.Import(test.LoadFile(t, "testdata/deck/commander_deck.json"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.DeckCount)
+	require.Len(t, deckService.Decks, 1)
+	assert.Equal(t, want, deckService.Decks[0])
+}
+
+func TestImportDeckWithoutDataFails(t *testing.T) {
+	importer := mtgjson.NewDeckImporter(&MockDeckService{})
+
+	_, err := importer.Import(strings.NewReader(`{"meta": {}}`))
+
+	assert.ErrorContains(t, err, "deck file contains no data")
+}
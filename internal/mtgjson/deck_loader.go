@@ -0,0 +1,181 @@
+package mtgjson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/rs/zerolog/log"
+)
+
+// DeckLoader Loads MTGJSON deck files. Unlike the FileLoader it supports sources with multiple files like a
+// directory or the AllDeckFiles zip archive.
+type DeckLoader struct {
+	dataset cards.Dataset
+	client  web.Client
+	store   storage.Storer
+}
+
+func NewDeckLoader(dataset cards.Dataset, wclient web.Client, store storage.Storer) *DeckLoader {
+	return &DeckLoader{
+		dataset: dataset,
+		client:  wclient,
+		store:   store,
+	}
+}
+
+// Load Imports all deck files of the source. The source can be a local json file, a local directory,
+// a local zip file or an url to a json or zip file.
+func (l *DeckLoader) Load(source *url.URL) (*cards.Report, error) {
+	if source.Scheme == "" {
+		return l.loadLocal(filepath.Clean(source.String()))
+	}
+
+	ctx := context.Background()
+	opts := web.NewGetOpts().WithExpectedCodes(200)
+	resp, err := l.client.Get(ctx, source.String(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download decks from %s due to %w", source, err)
+	}
+	defer aio.Close(resp.Body)
+
+	name := fmt.Sprintf("%d", time.Now().UnixMilli())
+	filename, err := resp.MimeType.BuildFilename(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sFile, err := l.store.Store(resp.Body, "downloads", filename)
+	if err != nil {
+		return nil, err
+	}
+	defer removeAll(sFile.AbsolutePath)
+
+	if !resp.MimeType.IsZip() {
+		return l.importFiles([]string{sFile.AbsolutePath})
+	}
+
+	dest := filepath.Join(filepath.Dir(sFile.AbsolutePath), name)
+	defer removeAll(dest)
+
+	return l.importZip(sFile.AbsolutePath, dest)
+}
+
+func (l *DeckLoader) loadLocal(path string) (*cards.Report, error) {
+	// #nosec G703 should be fine here
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s %w", path, err)
+	}
+
+	if info.IsDir() {
+		files, err := findJSONFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return l.importFiles(files)
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".json"):
+		return l.importFiles([]string{path})
+	case strings.HasSuffix(path, ".zip"):
+		dest, err := os.MkdirTemp("", "decks-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory %w", err)
+		}
+		defer removeAll(dest)
+
+		return l.importZip(path, dest)
+	default:
+		return nil, fmt.Errorf("only json or zip files are allowed, got %s", path)
+	}
+}
+
+func (l *DeckLoader) importZip(file string, dest string) (*cards.Report, error) {
+	log.Info().Msgf("Unzipping %s to %s", file, dest)
+	files, err := unzip(file, dest)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonFiles []string
+	for _, f := range files {
+		if strings.HasSuffix(f, ".json") {
+			jsonFiles = append(jsonFiles, f)
+		}
+	}
+	slices.Sort(jsonFiles)
+
+	return l.importFiles(jsonFiles)
+}
+
+func (l *DeckLoader) importFiles(files []string) (*cards.Report, error) {
+	report := &cards.Report{}
+	for i, f := range files {
+		r, err := l.importFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import deck file %s %w", f, err)
+		}
+		// the report contains the total amount of decks
+		report = r
+
+		if (i+1)%100 == 0 {
+			log.Info().Msgf("Processed %d of %d deck files", i+1, len(files))
+		}
+	}
+
+	return report, nil
+}
+
+func (l *DeckLoader) importFile(file string) (*cards.Report, error) {
+	// #nosec G703 should be fine here
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s %w", file, err)
+	}
+	defer aio.Close(f)
+
+	return l.dataset.Import(f)
+}
+
+func findJSONFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".json") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s %w", dir, err)
+	}
+
+	return files, nil
+}
+
+func removeAll(path string) {
+	// #nosec G703 is already sanitized
+	if err := os.RemoveAll(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Error().Err(err).Msgf("Failed to delete %s", path)
+
+		return
+	}
+	log.Info().Msgf("Delete %s", path)
+}
@@ -0,0 +1,111 @@
+package mtgjson_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/mtgjson"
+	"github.com/konstantinfoerster/card-importer-go/internal/storage"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingImporter struct {
+	files int
+}
+
+func (imp *countingImporter) Import(r io.Reader) (*cards.Report, error) {
+	if _, err := io.ReadAll(r); err != nil {
+		return nil, err
+	}
+	imp.files++
+
+	return &cards.Report{DeckCount: imp.files}, nil
+}
+
+func TestLoadDecks(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+	localStorage, err := storage.NewLocalStorage(config.Storage{Location: t.TempDir()})
+	require.NoErrorf(t, err, "failed to create local storate")
+	wclient := web.NewClient(web.Config{}, &http.Client{})
+
+	cases := []struct {
+		name      string
+		source    string
+		errPart   string
+		wantFiles int
+	}{
+		{
+			name:      "local json file",
+			source:    "testdata/deck/theme_deck.json",
+			wantFiles: 1,
+		},
+		{
+			name:      "local directory",
+			source:    "testdata/deck",
+			wantFiles: 2,
+		},
+		{
+			name:      "local zip file",
+			source:    "testdata/decks.zip",
+			wantFiles: 2,
+		},
+		{
+			name:      "local zip file ignores non json files",
+			source:    "testdata/two_files.zip",
+			wantFiles: 1,
+		},
+		{
+			name:    "local unsupported file",
+			source:  "testdata/unsupported.md",
+			errPart: "only json or zip files are allowed",
+		},
+		{
+			name:    "local file does not exists",
+			source:  "testdata/doesNotExists.json",
+			errPart: "failed to open",
+		},
+		{
+			name:      "external zip file",
+			source:    ts.URL + "/decks.zip",
+			wantFiles: 2,
+		},
+		{
+			name:      "external json file",
+			source:    ts.URL + "/deck/theme_deck.json",
+			wantFiles: 1,
+		},
+		{
+			name:    "external file not found",
+			source:  ts.URL + "/notFound.zip",
+			errPart: "not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			importer := &countingImporter{}
+			loader := mtgjson.NewDeckLoader(importer, wclient, localStorage)
+			u, err := url.Parse(tc.source)
+			require.NoError(t, err)
+
+			report, err := loader.Load(u)
+
+			if tc.errPart != "" {
+				require.ErrorContains(t, err, tc.errPart)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFiles, importer.files)
+			assert.Equal(t, tc.wantFiles, report.DeckCount)
+		})
+	}
+}
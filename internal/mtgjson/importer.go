@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -163,18 +165,83 @@ func mapToCardSet(s mtgjsonCardSet, langMapper cards.LanguageMapper) *cards.Card
 		}
 	}
 	set := &cards.CardSet{
-		Code:         strings.TrimSpace(s.Code),
-		Name:         strings.TrimSpace(s.Name),
-		TotalCount:   int(s.TotalCount),
-		Released:     released,
-		Block:        cards.CardBlock{Block: strings.TrimSpace(s.Block)},
-		Type:         strings.ToUpper(strings.TrimSpace(s.Type)),
-		Translations: translations,
+		Code:           strings.TrimSpace(s.Code),
+		Name:           strings.TrimSpace(s.Name),
+		TotalCount:     int(s.TotalCount),
+		Released:       released,
+		Block:          cards.CardBlock{Block: strings.TrimSpace(s.Block)},
+		Type:           strings.ToUpper(strings.TrimSpace(s.Type)),
+		Translations:   translations,
+		SealedProducts: mapToSealedProducts(s.SealedProducts),
+		Boosters:       mapToBoosters(s.Boosters),
 	}
 
 	return set
 }
 
+// mapToSealedProducts returns nil if the set does not contain sealed products.
+func mapToSealedProducts(products []sealedProduct) []cards.SealedProduct {
+	if products == nil {
+		return nil
+	}
+
+	result := make([]cards.SealedProduct, 0, len(products))
+	for _, p := range products {
+		var contents string
+		if len(p.Contents) > 0 && string(p.Contents) != "null" {
+			contents = string(p.Contents)
+		}
+		result = append(result, cards.SealedProduct{
+			MtgjsonID: strings.TrimSpace(p.UUID),
+			Name:      strings.TrimSpace(p.Name),
+			Category:  strings.TrimSpace(p.Category),
+			Subtype:   strings.TrimSpace(p.Subtype),
+			Contents:  contents,
+		})
+	}
+
+	return result
+}
+
+// mapToBoosters returns nil if the set does not contain booster configurations.
+func mapToBoosters(boosters map[string]booster) []cards.Booster {
+	if boosters == nil {
+		return nil
+	}
+
+	result := make([]cards.Booster, 0, len(boosters))
+	for _, name := range slices.Sorted(maps.Keys(boosters)) {
+		b := boosters[name]
+
+		variants := make([]cards.BoosterVariant, 0, len(b.Boosters))
+		for _, v := range b.Boosters {
+			variants = append(variants, cards.BoosterVariant{Weight: v.Weight, Contents: v.Contents})
+		}
+
+		sheets := make([]cards.BoosterSheet, 0, len(b.Sheets))
+		for _, sheetName := range slices.Sorted(maps.Keys(b.Sheets)) {
+			s := b.Sheets[sheetName]
+
+			sheetCards := make([]cards.SheetCard, 0, len(s.Cards))
+			for _, id := range slices.Sorted(maps.Keys(s.Cards)) {
+				sheetCards = append(sheetCards, cards.SheetCard{MtgjsonID: id, Weight: s.Cards[id]})
+			}
+			sheets = append(sheets, cards.BoosterSheet{
+				Name:            sheetName,
+				Foil:            s.Foil,
+				Fixed:           s.Fixed,
+				AllowDuplicates: s.AllowDuplicates,
+				BalanceColors:   s.BalanceColors,
+				Cards:           sheetCards,
+			})
+		}
+
+		result = append(result, cards.Booster{Name: name, Variants: variants, Sheets: sheets})
+	}
+
+	return result
+}
+
 func mapToCard(c mtgjsonCard, langMapper cards.LanguageMapper) (*cards.Card, error) {
 	multiverseID, err := strToInt(c.Identifiers.MultiverseID)
 	if err != nil {
@@ -218,6 +285,7 @@ func mapToCard(c mtgjsonCard, langMapper cards.LanguageMapper) (*cards.Card, err
 	}
 
 	face := &cards.Face{
+		MtgjsonID:         strings.TrimSpace(c.UUID),
 		Name:              strings.TrimSpace(name),
 		Artist:            strings.TrimSpace(c.Artist),
 		ConvertedManaCost: cmc,
@@ -40,6 +40,10 @@ func TestImportIntegration(t *testing.T) {
 	t.Run("Card Translations: create, update and remove", cardTranslations)
 	t.Run("Card all types: create, update and remove", cardTypes)
 	t.Run("Card types: duplicates", duplicatedCardTypes)
+	t.Run("Card prices: create and repeat", cardPrices)
+	t.Run("CardSet boosters and sealed products: create and replace", cardSetBoosters)
+	t.Run("Decks: create and replace", decks)
+	t.Run("Verify: load all cards", verifyCards)
 }
 
 func cardSetCreateAndUpdate(t *testing.T) {
@@ -167,10 +171,12 @@ func cardCreateUpdate(t *testing.T) {
 			Border:      "BLACK",
 			Faces: []*cards.Face{
 				{
+					MtgjsonID:         "100",
 					ConvertedManaCost: 6,
 					Name:              "Second Edition Updated",
 				},
 				{
+					MtgjsonID:         "110",
 					ConvertedManaCost: 8,
 					Name:              "The Second Updated",
 				},
@@ -198,12 +204,14 @@ func cardCreateUpdate(t *testing.T) {
 			Border:      "BLACK",
 			Faces: []*cards.Face{
 				{
-					Name: "Same Face Name",
-					Text: "Here is a text",
+					MtgjsonID: "200",
+					Name:      "Same Face Name",
+					Text:      "Here is a text",
 				},
 				{
-					Name: "Same Face Name",
-					Text: "Here is a text",
+					MtgjsonID: "200",
+					Name:      "Same Face Name",
+					Text:      "Here is a text",
 				},
 			},
 		},
@@ -431,6 +439,104 @@ func duplicatedCardTypes(t *testing.T) {
 	}
 }
 
+func cardPrices(t *testing.T) {
+	t.Cleanup(runner.Cleanup(t))
+
+	cDao := cards.NewCardDao(runner.Connection())
+	imp := mtgjson.NewImporter(cards.NewSetService(cards.NewSetDao(runner.Connection())), cards.NewCardService(cDao))
+	_, err := imp.Import(test.LoadFile(t, "testdata/card/one_card_no_references_update.json"))
+	require.NoError(t, err)
+	priceImp := mtgjson.NewPriceImporter(cards.NewPriceService(cards.NewPriceDao(runner.Connection()), cDao))
+
+	report, err := priceImp.Import(test.LoadFile(t, "testdata/price/prices_create.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.PriceCount)
+
+	// same day import does not add new entries
+	report, err = priceImp.Import(test.LoadFile(t, "testdata/price/prices_create.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.PriceCount)
+}
+
+func cardSetBoosters(t *testing.T) {
+	t.Cleanup(runner.Cleanup(t))
+
+	sDao := cards.NewSetDao(runner.Connection())
+	imp := mtgjson.NewImporter(cards.NewSetService(sDao), cards.NewCardService(cards.NewCardDao(runner.Connection())))
+
+	// second import replaces the existing entries
+	for range 2 {
+		_, err := imp.Import(test.LoadFile(t, "testdata/set/boosters_create.json"))
+		require.NoError(t, err)
+	}
+
+	products, err := sDao.FindSealedProducts("10E")
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "910", products[0].MtgjsonID)
+	assert.Equal(t, "booster_box", products[0].Category)
+	assert.Contains(t, products[0].Contents, `"uuid": "900"`)
+
+	booster, err := sDao.FindBooster("10E", "draft")
+	require.NoError(t, err)
+	assert.Len(t, booster.Variants, 2)
+	assert.Equal(t, map[string]int{"common": 2, "rare": 1}, booster.Variants[0].Contents)
+	require.Len(t, booster.Sheets, 3)
+	assert.Equal(t, "rare", booster.Sheets[2].Name)
+	assert.Equal(t, []cards.SheetCard{{MtgjsonID: "300", Weight: 1}, {MtgjsonID: "310", Weight: 2}}, booster.Sheets[2].Cards)
+
+	_, err = sDao.FindBooster("10E", "unknown")
+	assert.ErrorIs(t, err, cards.ErrEntryNotFound)
+}
+
+func decks(t *testing.T) {
+	t.Cleanup(runner.Cleanup(t))
+
+	imp := mtgjson.NewImporter(cards.NewSetService(cards.NewSetDao(runner.Connection())),
+		cards.NewCardService(cards.NewCardDao(runner.Connection())))
+	_, err := imp.Import(test.LoadFile(t, "testdata/card/one_card_no_references_update.json"))
+	require.NoError(t, err)
+	dDao := cards.NewDeckDao(runner.Connection())
+	deckImp := mtgjson.NewDeckImporter(cards.NewDeckService(dDao, cards.NewCardDao(runner.Connection())))
+	want := []cards.DeckEntry{
+		{Board: cards.DeckBoardMain, Count: 2, CardSetCode: "2ED", Number: "1"},
+		{Board: cards.DeckBoardSide, Count: 3, CardSetCode: "2ED", Number: "3"},
+		{Board: cards.DeckBoardCommander, Count: 1, Foil: true, CardSetCode: "2ED", Number: "4"},
+	}
+
+	// second import replaces the existing entries
+	for range 2 {
+		report, err := deckImp.Import(test.LoadFile(t, "testdata/deck/commander_deck.json"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.DeckCount)
+	}
+
+	deck, err := dDao.FindDeck("2ED", "Commander Deck")
+	require.NoError(t, err)
+	assert.Equal(t, "Commander Deck", deck.Type)
+	entries, err := dDao.FindEntries(deck.ID.Get())
+	require.NoError(t, err)
+	assert.Equal(t, want, entries)
+}
+
+func verifyCards(t *testing.T) {
+	t.Cleanup(runner.Cleanup(t))
+
+	cDao := cards.NewCardDao(runner.Connection())
+	imp := mtgjson.NewImporter(cards.NewSetService(cards.NewSetDao(runner.Connection())), cards.NewCardService(cDao))
+	_, err := imp.Import(test.LoadFile(t, "testdata/card/two_translations_create.json"))
+	require.NoError(t, err)
+
+	dbCards, err := cDao.FindAllForVerify()
+	require.NoError(t, err)
+
+	report := cards.Verify(dbCards, dbCards, true)
+	assert.False(t, report.HasFindings())
+	require.Len(t, dbCards, 1)
+	require.Len(t, dbCards[0].Faces, 1)
+	assert.Len(t, dbCards[0].Faces[0].Translations, 1)
+}
+
 func findUniqueCardWithReferences(t *testing.T, cDao *cards.PostgresCardDao, setCode string, number string) *cards.Card {
 	t.Helper()
 
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 	"github.com/konstantinfoerster/card-importer-go/internal/mtgjson"
@@ -236,24 +237,31 @@ func TestImportCardWithMultipleFaces(t *testing.T) {
 					Border:      "WHITE",
 					Faces: []*cards.Face{
 						{
+							MtgjsonID:         "5",
 							Name:              "Five",
 							ConvertedManaCost: 5.0,
 						}, {
+							MtgjsonID:         "3",
 							Name:              "Four",
 							ConvertedManaCost: 4.0,
 						}, {
+							MtgjsonID:         "1",
 							Name:              "One",
 							ConvertedManaCost: 1.0,
 						}, {
+							MtgjsonID:         "7",
 							Name:              "Seven",
 							ConvertedManaCost: 7.0,
 						}, {
+							MtgjsonID:         "6",
 							Name:              "Six",
 							ConvertedManaCost: 6.0,
 						}, {
+							MtgjsonID:         "4",
 							Name:              "Three",
 							ConvertedManaCost: 3.0,
 						}, {
+							MtgjsonID:         "2",
 							Name:              "Two",
 							ConvertedManaCost: 2.0,
 						},
@@ -330,3 +338,76 @@ func TestImportCardWithInvalidFaces(t *testing.T) {
 		})
 	}
 }
+
+func TestImportSetWithBoostersAndSealedProducts(t *testing.T) {
+	setService := MockSetService{}
+	cardService := MockCardService{}
+	importer := mtgjson.NewImporter(&setService, &cardService)
+	want := cards.CardSet{
+		Code:     "10E",
+		Name:     "Tenth Edition",
+		Released: time.Date(2007, time.July, 13, 0, 0, 0, 0, time.UTC),
+		Type:     "CORE",
+		SealedProducts: []cards.SealedProduct{
+			{
+				MtgjsonID: "910",
+				Name:      "Tenth Edition Draft Booster Box",
+				Category:  "booster_box",
+				Subtype:   "draft",
+			},
+		},
+		Boosters: []cards.Booster{
+			{
+				Name: "draft",
+				Variants: []cards.BoosterVariant{
+					{Weight: 3, Contents: map[string]int{"common": 2, "rare": 1}},
+					{Weight: 1, Contents: map[string]int{"common": 2, "foil": 1}},
+				},
+				Sheets: []cards.BoosterSheet{
+					{
+						Name:          "common",
+						BalanceColors: true,
+						Cards: []cards.SheetCard{
+							{MtgjsonID: "100", Weight: 1},
+							{MtgjsonID: "110", Weight: 1},
+							{MtgjsonID: "120", Weight: 1},
+						},
+					},
+					{
+						Name:            "foil",
+						Foil:            true,
+						AllowDuplicates: true,
+						Cards:           []cards.SheetCard{{MtgjsonID: "100", Weight: 1}},
+					},
+					{
+						Name:  "rare",
+						Cards: []cards.SheetCard{{MtgjsonID: "300", Weight: 1}, {MtgjsonID: "310", Weight: 2}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := importer.Import(test.LoadFile(t, "testdata/set/boosters_create.json"))
+
+	require.NoError(t, err)
+	require.Len(t, setService.Sets, 1)
+	got := setService.Sets[0]
+	require.Len(t, got.SealedProducts, 1)
+	assert.Contains(t, got.SealedProducts[0].Contents, `"uuid": "900"`)
+	got.SealedProducts[0].Contents = ""
+	assert.Equal(t, want, got)
+}
+
+func TestImportSetWithoutBoostersKeepsNil(t *testing.T) {
+	setService := MockSetService{}
+	cardService := MockCardService{}
+	importer := mtgjson.NewImporter(&setService, &cardService)
+
+	_, err := importer.Import(test.LoadFile(t, "testdata/set/set_no_cards_create.json"))
+
+	require.NoError(t, err)
+	require.Len(t, setService.Sets, 1)
+	assert.Nil(t, setService.Sets[0].SealedProducts)
+	assert.Nil(t, setService.Sets[0].Boosters)
+}
@@ -1,15 +1,53 @@
 package mtgjson
 
-import "strings"
+import (
+	"encoding/json"
+	"strings"
+)
 
 type mtgjsonCardSet struct {
-	Code         string        `json:"code"`
-	Name         string        `json:"name"`
-	Block        string        `json:"block"`
-	Type         string        `json:"type"`
-	TotalCount   float64       `json:"totalSetSize"`
-	Released     string        `json:"releaseDate"`
-	Translations []translation `json:"translations"`
+	Code           string             `json:"code"`
+	Name           string             `json:"name"`
+	Block          string             `json:"block"`
+	Type           string             `json:"type"`
+	TotalCount     float64            `json:"totalSetSize"`
+	Released       string             `json:"releaseDate"`
+	Translations   []translation      `json:"translations"`
+	Boosters       map[string]booster `json:"booster"`
+	SealedProducts []sealedProduct    `json:"sealedProduct"`
+}
+
+// booster the configuration of a booster type (draft, collector ...) of a set.
+type booster struct {
+	Name                string                  `json:"name"`
+	Boosters            []boosterVariant        `json:"boosters"`
+	BoostersTotalWeight int                     `json:"boostersTotalWeight"`
+	Sheets              map[string]boosterSheet `json:"sheets"`
+}
+
+// boosterVariant a possible booster content, keyed by sheet name with the amount of cards taken from the sheet.
+type boosterVariant struct {
+	Contents map[string]int `json:"contents"`
+	Weight   int            `json:"weight"`
+}
+
+// boosterSheet the cards (uuid with weight) that can be opened from a sheet.
+type boosterSheet struct {
+	AllowDuplicates bool           `json:"allowDuplicates"`
+	BalanceColors   bool           `json:"balanceColors"`
+	Cards           map[string]int `json:"cards"`
+	Fixed           bool           `json:"fixed"`
+	Foil            bool           `json:"foil"`
+	TotalWeight     int            `json:"totalWeight"`
+}
+
+type sealedProduct struct {
+	UUID        string          `json:"uuid"`
+	Name        string          `json:"name"`
+	Category    string          `json:"category"`
+	Subtype     string          `json:"subtype"`
+	ReleaseDate string          `json:"releaseDate"`
+	Contents    json.RawMessage `json:"contents"`
 }
 
 type translation struct {
@@ -73,3 +111,41 @@ type foreignData struct {
 type identifier struct {
 	MultiverseID string `json:"multiverseId"`
 }
+
+// mtgjsonCardPrices all prices of one card. Formats are keyed by the medium (paper, mtgo) and the provider
+// (cardmarket, tcgplayer ...).
+type mtgjsonCardPrices struct {
+	UUID    string
+	Formats map[string]map[string]priceList
+}
+
+// priceList prices of a provider, keyed by the finish (normal, foil, etched) and the date (YYYY-MM-DD).
+type priceList struct {
+	Currency string                        `json:"currency"`
+	Retail   map[string]map[string]float64 `json:"retail"`
+	Buylist  map[string]map[string]float64 `json:"buylist"`
+}
+
+// mtgjsonDeckFile the content of a single deck file e.g. from the AllDeckFiles archive.
+type mtgjsonDeckFile struct {
+	Data *mtgjsonDeck `json:"data"`
+}
+
+type mtgjsonDeck struct {
+	Code        string             `json:"code"`
+	Name        string             `json:"name"`
+	ReleaseDate string             `json:"releaseDate"`
+	Type        string             `json:"type"`
+	MainBoard   []mtgjsonDeckEntry `json:"mainBoard"`
+	SideBoard   []mtgjsonDeckEntry `json:"sideBoard"`
+	Commander   []mtgjsonDeckEntry `json:"commander"`
+}
+
+// mtgjsonDeckEntry a card of a deck, only the fields required to reference the card are decoded.
+type mtgjsonDeckEntry struct {
+	UUID   string `json:"uuid"`
+	Code   string `json:"setCode"`
+	Number string `json:"number"`
+	Count  int    `json:"count"`
+	Foil   bool   `json:"isFoil"`
+}
@@ -0,0 +1,146 @@
+package mtgjson
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+var priceMediums = map[string]string{
+	"paper": cards.PriceMediumPaper,
+	"mtgo":  cards.PriceMediumOnline,
+}
+
+type mtgJSONPrices struct {
+	priceService cards.Service[*cards.CardPrices]
+}
+
+// NewPriceImporter Creates a dataset that imports the MTGJSON AllPrices.json or AllPricesToday.json file.
+func NewPriceImporter(priceService cards.Service[*cards.CardPrices]) cards.Dataset {
+	return &mtgJSONPrices{
+		priceService: priceService,
+	}
+}
+
+func (imp *mtgJSONPrices) Import(r io.Reader) (*cards.Report, error) {
+	errg, ctx := errgroup.WithContext(context.Background())
+
+	processed := 0
+	for r := range parsePrices(ctx, r) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		v, ok := r.Result.(mtgjsonCardPrices)
+		if !ok {
+			return nil, fmt.Errorf("found unknown result type %T", r.Result)
+		}
+
+		entry, err := mapToCardPrices(v)
+		if err != nil {
+			return nil, err
+		}
+
+		errg.Go(func() error {
+			return imp.priceService.Import(entry)
+		})
+
+		processed++
+		if processed%10000 == 0 {
+			log.Info().Msgf("Processed prices of %d cards", processed)
+		}
+	}
+
+	if err := errg.Wait(); err != nil {
+		return nil, err
+	}
+
+	priceCount, err := imp.priceService.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cards.Report{
+		PriceCount: priceCount,
+	}, nil
+}
+
+func mapToCardPrices(p mtgjsonCardPrices) (*cards.CardPrices, error) {
+	var prices []cards.CardPrice
+	for medium, providers := range p.Formats {
+		mappedMedium, ok := priceMediums[strings.ToLower(strings.TrimSpace(medium))]
+		if !ok {
+			if e := log.Debug(); e.Enabled() {
+				e.Msgf("Skip prices with unsupported medium %s of card %s", medium, p.UUID)
+			}
+
+			continue
+		}
+
+		for provider, list := range providers {
+			base := cards.CardPrice{
+				Provider: strings.ToLower(strings.TrimSpace(provider)),
+				Medium:   mappedMedium,
+				Currency: strings.ToUpper(strings.TrimSpace(list.Currency)),
+			}
+
+			retail, err := mapPricePoints(base, cards.PriceTypeRetail, list.Retail)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retail prices of card %s and provider %s %w", p.UUID, provider, err)
+			}
+			buylist, err := mapPricePoints(base, cards.PriceTypeBuylist, list.Buylist)
+			if err != nil {
+				return nil, fmt.Errorf("invalid buylist prices of card %s and provider %s %w", p.UUID, provider, err)
+			}
+
+			prices = append(prices, retail...)
+			prices = append(prices, buylist...)
+		}
+	}
+
+	// map iteration order is random, keep the result stable
+	slices.SortFunc(prices, func(a, b cards.CardPrice) int {
+		return cmp.Or(
+			cmp.Compare(a.Medium, b.Medium),
+			cmp.Compare(a.Provider, b.Provider),
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.Finish, b.Finish),
+			a.Date.Compare(b.Date),
+		)
+	})
+
+	return &cards.CardPrices{
+		MtgjsonID: strings.TrimSpace(p.UUID),
+		Prices:    prices,
+	}, nil
+}
+
+func mapPricePoints(base cards.CardPrice, priceType string, finishes map[string]map[string]float64) (
+	[]cards.CardPrice, error) {
+	var prices []cards.CardPrice
+	for finish, days := range finishes {
+		for day, price := range days {
+			date, err := time.Parse("2006-01-02", strings.TrimSpace(day)) // ISO 8601 YYYY-MM-DD
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse price date %s %w", day, err)
+			}
+
+			p := base
+			p.Type = priceType
+			p.Finish = strings.ToLower(strings.TrimSpace(finish))
+			p.Date = date
+			p.Price = price
+			prices = append(prices, p)
+		}
+	}
+
+	return prices, nil
+}
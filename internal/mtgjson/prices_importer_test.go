@@ -0,0 +1,99 @@
+package mtgjson_test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/mtgjson"
+	"github.com/konstantinfoerster/card-importer-go/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPriceService struct {
+	mu         sync.Mutex
+	Prices     []cards.CardPrices
+	FakeImport func(prices *cards.CardPrices) error
+}
+
+func (s *MockPriceService) Import(prices *cards.CardPrices) error {
+	// will be called concurrently from the importer
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.FakeImport != nil {
+		if err := s.FakeImport(prices); err != nil {
+			return err
+		}
+	}
+
+	s.Prices = append(s.Prices, *prices)
+
+	return nil
+}
+
+func (s *MockPriceService) Count() (int, error) {
+	count := 0
+	for _, p := range s.Prices {
+		count += len(p.Prices)
+	}
+
+	return count, nil
+}
+
+func TestImportPrices(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC)
+	}
+	want := []cards.CardPrices{
+		{
+			MtgjsonID: "00010d56-fe38-5e35-8aed-518019aa36a5",
+			Prices: []cards.CardPrice{
+				{Date: day(1), Provider: "cardhoarder", Medium: "ONLINE", Type: "RETAIL", Finish: "normal",
+					Currency: "USD", Price: 0.02},
+				{Date: day(2), Provider: "cardkingdom", Medium: "PAPER", Type: "BUYLIST", Finish: "normal",
+					Currency: "USD", Price: 0.05},
+				{Date: day(1), Provider: "cardmarket", Medium: "PAPER", Type: "RETAIL", Finish: "foil",
+					Currency: "EUR", Price: 0.85},
+				{Date: day(1), Provider: "cardmarket", Medium: "PAPER", Type: "RETAIL", Finish: "normal",
+					Currency: "EUR", Price: 0.1},
+				{Date: day(2), Provider: "cardmarket", Medium: "PAPER", Type: "RETAIL", Finish: "normal",
+					Currency: "EUR", Price: 0.12},
+			},
+		},
+		{
+			MtgjsonID: "0001e0d0-2dcd-5640-aadc-a84765cf5fc9",
+		},
+		{
+			MtgjsonID: "0003caab-9ff5-5d1a-bc06-976dd0457f19",
+		},
+	}
+	priceService := MockPriceService{}
+
+	importer := mtgjson.NewPriceImporter(&priceService)
+	report, err := importer.Import(test.LoadFile(t, "testdata/prices.json"))
+
+	require.NoError(t, err)
+	sort.Slice(priceService.Prices, func(i, j int) bool {
+		return priceService.Prices[i].MtgjsonID < priceService.Prices[j].MtgjsonID
+	})
+	assert.Equal(t, want, priceService.Prices)
+	assert.Equal(t, 5, report.PriceCount)
+}
+
+func TestImportPricesWithImportError(t *testing.T) {
+	priceService := MockPriceService{
+		FakeImport: func(prices *cards.CardPrices) error {
+			return fmt.Errorf("price import failed [%s]", prices.MtgjsonID)
+		},
+	}
+
+	importer := mtgjson.NewPriceImporter(&priceService)
+	_, err := importer.Import(test.LoadFile(t, "testdata/prices.json"))
+
+	assert.ErrorContains(t, err, "price import failed")
+}
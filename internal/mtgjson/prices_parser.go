@@ -0,0 +1,88 @@
+package mtgjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// parsePrices streams the content of the AllPrices.json or AllPricesToday.json file.
+// Each result contains the prices of one card.
+func parsePrices(ctx context.Context, r io.Reader) <-chan result {
+	c := make(chan result)
+
+	go func() {
+		defer close(c)
+
+		dec := json.NewDecoder(r)
+
+		if err := expectNext(json.Delim('{'), dec); err != nil {
+			c <- result{Result: nil, Err: err}
+
+			return
+		}
+
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				c <- result{Result: nil, Err: err}
+
+				return
+			}
+
+			if t != "data" {
+				if err := skip(dec); err != nil {
+					c <- result{Result: nil, Err: err}
+
+					return
+				}
+
+				continue
+			}
+
+			if err := parseCardPrices(ctx, dec, c); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c <- result{Result: nil, Err: err}
+
+				return
+			}
+		}
+	}()
+
+	return c
+}
+
+func parseCardPrices(ctx context.Context, dec *json.Decoder, c chan<- result) error {
+	if err := expectNext(json.Delim('{'), dec); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		uuid, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("card uuid is not a string but %T", t)
+		}
+
+		var formats map[string]map[string]priceList
+		if err := dec.Decode(&formats); err != nil {
+			return fmt.Errorf("failed to decode prices of card %s %w", uuid, err)
+		}
+
+		c <- result{Result: mtgjsonCardPrices{UUID: uuid, Formats: formats}, Err: nil}
+	}
+
+	return expectNext(json.Delim('}'), dec)
+}
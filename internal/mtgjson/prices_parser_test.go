@@ -0,0 +1,95 @@
+package mtgjson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePricesInvalidJsonStart(t *testing.T) {
+	r := strings.NewReader(`[]`)
+	expected := "expected token to be"
+
+	ch := parsePrices(t.Context(), r)
+	actual := <-ch
+
+	assert.Contains(t, actual.Err.Error(), expected)
+	assertChannelClosed(t, ch)
+}
+
+func TestParsePricesInvalidPriceList(t *testing.T) {
+	r := strings.NewReader(`{"data": {"1": {"paper": []}}}`)
+	expected := "failed to decode prices of card 1"
+
+	ch := parsePrices(t.Context(), r)
+	actual := <-ch
+
+	assert.Contains(t, actual.Err.Error(), expected)
+	assertChannelClosed(t, ch)
+}
+
+func TestParsePrices(t *testing.T) {
+	want := []mtgjsonCardPrices{
+		{
+			UUID: "00010d56-fe38-5e35-8aed-518019aa36a5",
+			Formats: map[string]map[string]priceList{
+				"mtgo": {
+					"cardhoarder": {
+						Currency: "USD",
+						Retail: map[string]map[string]float64{
+							"normal": {"2024-05-01": 0.02},
+						},
+					},
+				},
+				"paper": {
+					"cardmarket": {
+						Currency: "EUR",
+						Buylist:  map[string]map[string]float64{},
+						Retail: map[string]map[string]float64{
+							"foil":   {"2024-05-01": 0.85},
+							"normal": {"2024-05-01": 0.1, "2024-05-02": 0.12},
+						},
+					},
+					"cardkingdom": {
+						Currency: "USD",
+						Buylist: map[string]map[string]float64{
+							"normal": {"2024-05-02": 0.05},
+						},
+					},
+				},
+			},
+		},
+		{
+			UUID: "0001e0d0-2dcd-5640-aadc-a84765cf5fc9",
+			Formats: map[string]map[string]priceList{
+				"arena": {
+					"unknown": {
+						Currency: "USD",
+						Retail: map[string]map[string]float64{
+							"normal": {"2024-05-02": 1},
+						},
+					},
+				},
+				"paper": {},
+			},
+		},
+		{
+			UUID:    "0003caab-9ff5-5d1a-bc06-976dd0457f19",
+			Formats: map[string]map[string]priceList{},
+		},
+	}
+
+	var actual []mtgjsonCardPrices
+	for r := range parsePrices(t.Context(), test.LoadFile(t, "testdata/prices.json")) {
+		require.NoError(t, r.Err)
+
+		p, ok := r.Result.(mtgjsonCardPrices)
+		require.True(t, ok)
+		actual = append(actual, p)
+	}
+
+	assert.Equal(t, want, actual)
+}
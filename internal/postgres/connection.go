@@ -113,7 +113,22 @@ func (d *DBConnection) Cleanup() error {
 		"sub_type_translation",
 		"sub_type",
 
+		"card_image_rendition",
+		"card_image_miss",
 		"card_image",
+		"image_blob",
+		"card_symbol",
+
+		"card_price",
+
+		"sealed_product",
+		"booster_sheet_card",
+		"booster_sheet",
+		"booster_variant",
+		"booster",
+
+		"deck_card",
+		"deck",
 	}
 	_, err := d.Conn.Exec(context.TODO(), fmt.Sprintf("TRUNCATE %s RESTART IDENTITY", strings.Join(tables, ",")))
 
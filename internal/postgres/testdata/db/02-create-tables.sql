@@ -275,3 +275,196 @@ ALTER TYPE layout ADD VALUE 'MUTATE';
 ALTER TYPE layout ADD VALUE 'CASE';
 ALTER TYPE card_set_type ADD VALUE 'MINIGAME';
 
+
+ALTER TABLE card_face ADD COLUMN mtgjson_id VARCHAR(36); -- uuid of the face in the MTGJSON dataset
+CREATE INDEX idx_card_face_mtgjson_id on card_face(mtgjson_id);
+
+-- Card Price --
+CREATE TYPE price_medium AS ENUM (
+    'PAPER',
+    'ONLINE'
+    );
+
+CREATE TYPE price_type AS ENUM (
+    'RETAIL',
+    'BUYLIST'
+    );
+
+-- append only, one entry per card, day, provider, medium, type, finish and currency
+CREATE TABLE card_price
+(
+    id         BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_id    INTEGER        NOT NULL REFERENCES card (id) ON DELETE CASCADE,
+    price_date DATE           NOT NULL,
+    provider   VARCHAR(100)   NOT NULL CHECK ( provider <> '' ),
+    medium     price_medium   NOT NULL, -- Enum
+    price_type price_type     NOT NULL, -- Enum
+    finish     VARCHAR(50)    NOT NULL CHECK ( finish <> '' ),
+    currency   CHAR(3)        NOT NULL CHECK ( currency <> '' ),
+    price      NUMERIC(12, 2) NOT NULL CHECK ( price >= 0 ),
+    UNIQUE (card_id, price_date, provider, medium, price_type, finish, currency)
+);
+
+-- Sealed Product --
+CREATE TABLE sealed_product
+(
+    id            INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    mtgjson_id    VARCHAR(36)  NOT NULL UNIQUE CHECK ( mtgjson_id <> '' ),
+    name          VARCHAR(255) NOT NULL CHECK ( name <> '' ),
+    category      VARCHAR(100),
+    subtype       VARCHAR(100),
+    contents      JSONB,
+    card_set_code VARCHAR(10)  NOT NULL REFERENCES card_set (code) ON DELETE CASCADE
+);
+
+-- Booster --
+CREATE TABLE booster
+(
+    id            INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL CHECK ( name <> '' ),
+    card_set_code VARCHAR(10)  NOT NULL REFERENCES card_set (code) ON DELETE CASCADE,
+    UNIQUE (card_set_code, name)
+);
+
+CREATE TABLE booster_variant
+(
+    id         INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    weight     INTEGER NOT NULL CHECK ( weight >= 0 ),
+    contents   JSONB   NOT NULL, -- amount of cards per sheet name
+    booster_id INTEGER NOT NULL REFERENCES booster (id) ON DELETE CASCADE
+);
+
+CREATE TABLE booster_sheet
+(
+    id               INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name             VARCHAR(100) NOT NULL CHECK ( name <> '' ),
+    foil             BOOLEAN      NOT NULL DEFAULT FALSE,
+    fixed            BOOLEAN      NOT NULL DEFAULT FALSE,
+    allow_duplicates BOOLEAN      NOT NULL DEFAULT FALSE,
+    balance_colors   BOOLEAN      NOT NULL DEFAULT FALSE,
+    booster_id       INTEGER      NOT NULL REFERENCES booster (id) ON DELETE CASCADE,
+    UNIQUE (booster_id, name)
+);
+
+CREATE TABLE booster_sheet_card
+(
+    mtgjson_id VARCHAR(36) NOT NULL CHECK ( mtgjson_id <> '' ),
+    weight     INTEGER     NOT NULL CHECK ( weight >= 0 ),
+    sheet_id   INTEGER     NOT NULL REFERENCES booster_sheet (id) ON DELETE CASCADE,
+    PRIMARY KEY (sheet_id, mtgjson_id)
+);
+
+-- Deck --
+CREATE TYPE deck_board AS ENUM (
+    'MAIN',
+    'SIDE',
+    'COMMANDER'
+    );
+
+CREATE TABLE deck
+(
+    id            INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_set_code VARCHAR(10)  NOT NULL CHECK ( card_set_code <> '' ),
+    name          VARCHAR(255) NOT NULL CHECK ( name <> '' ),
+    deck_type     VARCHAR(100),
+    released      DATE,
+    UNIQUE (card_set_code, name)
+);
+
+CREATE TABLE deck_card
+(
+    deck_id INTEGER    NOT NULL REFERENCES deck (id) ON DELETE CASCADE,
+    card_id INTEGER    NOT NULL REFERENCES card (id) ON DELETE CASCADE,
+    board   deck_board NOT NULL, -- Enum
+    foil    BOOLEAN    NOT NULL DEFAULT FALSE,
+    count   INTEGER    NOT NULL CHECK ( count > 0 ),
+    PRIMARY KEY (deck_id, card_id, board, foil)
+);
+
+-- Card Image Variants --
+ALTER TABLE card_image ADD COLUMN variant VARCHAR(20) NOT NULL DEFAULT 'normal' CHECK ( variant <> '' ); -- e.g. normal, small, art_crop
+CREATE UNIQUE INDEX idx_card_image_face_lang_variant on card_image(face_id, lang_lang, variant);
+
+-- Card Image Hashes --
+ALTER TABLE card_image ADD COLUMN ahash BIT VARYING(4096); -- average hash
+ALTER TABLE card_image ADD COLUMN dhash BIT VARYING(4096); -- difference hash
+CREATE INDEX idx_card_image_ahash on card_image(ahash);
+CREATE INDEX idx_card_image_dhash on card_image(dhash);
+
+-- Card Image Renditions --
+CREATE TABLE card_image_rendition
+(
+    id            INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_image_id INTEGER      NOT NULL REFERENCES card_image (id) ON DELETE CASCADE,
+    name          VARCHAR(20)  NOT NULL CHECK ( name <> '' ), -- e.g. w146 or gray
+    image_path    VARCHAR(255) NOT NULL CHECK ( image_path <> '' ),
+    mime_type     VARCHAR(100) NOT NULL CHECK ( mime_type <> '' ),
+    width         INTEGER      NOT NULL CHECK ( width > 0 ),
+    height        INTEGER      NOT NULL CHECK ( height > 0 ),
+    UNIQUE (card_image_id, name),
+    UNIQUE (image_path)
+);
+
+-- Card Image Language Fallback --
+ALTER TABLE card_image ADD COLUMN source_lang CHAR(3) REFERENCES lang (lang); -- language of the stored image
+ALTER TABLE card_image ADD COLUMN fallback BOOLEAN NOT NULL DEFAULT false; -- true if the image of another language is stored
+CREATE INDEX idx_card_image_fallback on card_image(fallback) WHERE fallback;
+-- images that are identical to the english image of the face are fallback images
+UPDATE card_image AS ci
+SET source_lang = 'eng', fallback = true
+FROM card_image AS en
+WHERE ci.lang_lang <> 'eng' AND en.lang_lang = 'eng' AND en.face_id = ci.face_id AND en.variant = ci.variant
+  AND ci.phash1 = en.phash1 AND ci.phash2 = en.phash2 AND ci.phash3 = en.phash3 AND ci.phash4 = en.phash4;
+UPDATE card_image SET source_lang = lang_lang WHERE source_lang IS NULL;
+
+-- Card Image Misses --
+CREATE TABLE card_image_miss
+(
+    id           INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    card_id      INTEGER     NOT NULL CHECK (card_id >= 0),
+    face_id      INTEGER     NOT NULL,
+    lang_lang    CHAR(3)     NOT NULL REFERENCES lang (lang),
+    variant      VARCHAR(20) NOT NULL CHECK ( variant <> '' ),
+    reason       VARCHAR(20) NOT NULL CHECK ( reason <> '' ), -- e.g. card_not_found, image_not_found, image_broken
+    attempts     INTEGER     NOT NULL CHECK ( attempts > 0 ),
+    last_attempt TIMESTAMPTZ NOT NULL,
+    next_retry   TIMESTAMPTZ NOT NULL,
+    UNIQUE (face_id, lang_lang, variant)
+);
+
+-- Card Image Validators --
+ALTER TABLE card_image ADD COLUMN etag VARCHAR(255) NOT NULL DEFAULT ''; -- etag of the downloaded image
+ALTER TABLE card_image ADD COLUMN last_modified VARCHAR(64) NOT NULL DEFAULT ''; -- last modified date of the downloaded image
+
+-- Content Addressed Image Blobs --
+CREATE TABLE image_blob
+(
+    id         INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    sha256     CHAR(64)     NOT NULL,
+    image_path VARCHAR(255) NOT NULL CHECK ( image_path <> '' ),
+    mime_type  VARCHAR(100) NOT NULL CHECK ( mime_type <> '' ),
+    ref_count  INTEGER      NOT NULL CHECK ( ref_count >= 0 ), -- amount of card images that use the blob
+    UNIQUE (sha256),
+    UNIQUE (image_path)
+);
+ALTER TABLE card_image ADD COLUMN blob_id INTEGER REFERENCES image_blob (id); -- shared file, null if not content addressed
+CREATE INDEX idx_card_image_blob on card_image(blob_id);
+-- content addressed images share the path of their blob
+ALTER TABLE card_image DROP CONSTRAINT card_image_image_path_key;
+CREATE UNIQUE INDEX idx_card_image_path on card_image(image_path) WHERE blob_id IS NULL;
+
+-- Set Icons and Symbols --
+ALTER TABLE card_set ADD COLUMN icon_path VARCHAR(255); -- stored icon of the set, null if not downloaded
+CREATE TABLE card_symbol
+(
+    id         INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    symbol     VARCHAR(20)  NOT NULL CHECK ( symbol <> '' ), -- e.g. {W} or {T}
+    name       VARCHAR(255) NOT NULL,                         -- english description e.g. one white mana
+    image_path VARCHAR(255) NOT NULL CHECK ( image_path <> '' ),
+    mime_type  VARCHAR(100) NOT NULL CHECK ( mime_type <> '' ),
+    UNIQUE (symbol)
+);
+
+-- Card Image Verification --
+ALTER TABLE card_image ADD COLUMN verified BOOLEAN NOT NULL DEFAULT false; -- true if the source confirmed language, set and number
+ALTER TABLE card_image ADD COLUMN mismatch VARCHAR(255) NOT NULL DEFAULT ''; -- why the image of the requested language was rejected
@@ -0,0 +1,74 @@
+package scryfall
+
+// BulkData The description of a bulk data file e.g. default_cards or all_cards.
+type BulkData struct {
+	Type        string `json:"type"`
+	DownloadURI string `json:"download_uri"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// Set A card set as returned by the sets endpoint.
+type Set struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	SetType    string `json:"set_type"`
+	ReleasedAt string `json:"released_at"`
+	Block      string `json:"block"`
+	CardCount  int    `json:"card_count"`
+	IconSvgURI string `json:"icon_svg_uri"`
+}
+
+type setList struct {
+	Data     []Set  `json:"data"`
+	HasMore  bool   `json:"has_more"`
+	NextPage string `json:"next_page"`
+}
+
+// CardSymbol A mana or cost symbol as returned by the symbology endpoint.
+type CardSymbol struct {
+	Symbol  string `json:"symbol"`
+	English string `json:"english"`
+	SvgURI  string `json:"svg_uri"`
+}
+
+type symbolList struct {
+	Data []CardSymbol `json:"data"`
+}
+
+// BulkCard A card of a bulk data file. Only the fields required for the dataset import are decoded.
+type BulkCard struct {
+	Lang            string         `json:"lang"`
+	Name            string         `json:"name"`
+	Set             string         `json:"set"`
+	SetName         string         `json:"set_name"`
+	SetType         string         `json:"set_type"`
+	ReleasedAt      string         `json:"released_at"`
+	CollectorNumber string         `json:"collector_number"`
+	Rarity          string         `json:"rarity"`
+	BorderColor     string         `json:"border_color"`
+	Layout          string         `json:"layout"`
+	MultiverseIDs   []int          `json:"multiverse_ids"`
+	Faces           []BulkCardFace `json:"card_faces"`
+	BulkCardFace
+}
+
+// BulkCardFace The face fields of a card. Single faced cards contain them at the top level.
+type BulkCardFace struct {
+	Name            string   `json:"name"`
+	Artist          string   `json:"artist"`
+	Cmc             *float64 `json:"cmc"`
+	Colors          []string `json:"colors"`
+	ManaCost        string   `json:"mana_cost"`
+	TypeLine        string   `json:"type_line"`
+	OracleText      string   `json:"oracle_text"`
+	FlavorText      string   `json:"flavor_text"`
+	Power           string   `json:"power"`
+	Toughness       string   `json:"toughness"`
+	Loyalty         string   `json:"loyalty"`
+	HandModifier    string   `json:"hand_modifier"`
+	LifeModifier    string   `json:"life_modifier"`
+	PrintedName     string   `json:"printed_name"`
+	PrintedText     string   `json:"printed_text"`
+	PrintedTypeLine string   `json:"printed_type_line"`
+	ImgUris         ImgURIs  `json:"image_uris"`
+}
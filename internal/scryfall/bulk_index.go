@@ -0,0 +1,111 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/rs/zerolog/log"
+)
+
+// BulkIndex An in-memory index of the image urls of a bulk data file, identified by set code, number and language.
+type BulkIndex struct {
+	entries map[string]*Card
+}
+
+// NewBulkIndex Reads the image urls of all cards of supported languages from a bulk data file.
+func NewBulkIndex(r io.Reader, languages cards.LanguageMapper) (*BulkIndex, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	idx := &BulkIndex{entries: map[string]*Card{}}
+	for r := range parseBulk(ctx, r) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		bc := r.Result
+		lang := languages.ByExternal(bc.Lang)
+		if lang == "" {
+			continue
+		}
+
+		faces := make([]CardFace, 0, len(bc.Faces))
+		for _, f := range bc.Faces {
+			faces = append(faces, CardFace{Name: f.Name, ImgUris: f.ImgUris})
+		}
+		idx.entries[indexKey(bc.Set, bc.CollectorNumber, lang)] = &Card{
+			Name:            bc.Name,
+			Lang:            bc.Lang,
+			Set:             bc.Set,
+			CollectorNumber: bc.CollectorNumber,
+			ImgUris:         bc.ImgUris,
+			Faces:           faces,
+		}
+	}
+
+	return idx, nil
+}
+
+// Find Returns the card with the given set code, number and language e.g. deu.
+func (i *BulkIndex) Find(setCode, number, lang string) (*Card, bool) {
+	c, ok := i.entries[indexKey(setCode, number, lang)]
+
+	return c, ok
+}
+
+// Len Returns the amount of indexed cards.
+func (i *BulkIndex) Len() int {
+	return len(i.entries)
+}
+
+func indexKey(setCode, number, lang string) string {
+	return fmt.Sprintf("%s_%s_%s", strings.ToUpper(strings.TrimSpace(setCode)), strings.TrimSpace(number),
+		strings.ToLower(strings.TrimSpace(lang)))
+}
+
+// LoadBulkIndex Creates the index from the configured local file or downloads the configured bulk data type.
+// The downloaded file is not stored, it is indexed while reading. The file is downloaded with the given download
+// client, it must not limit the time to read the body, because the bulk data files have multiple gigabytes.
+func LoadBulkIndex(ctx context.Context, client *Client, download web.Client, cfg config.ImageIndex) (*BulkIndex,
+	error) {
+	if strings.TrimSpace(cfg.File) != "" {
+		f, err := os.Open(filepath.Clean(cfg.File))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open bulk data file %s %w", cfg.File, err)
+		}
+		defer aio.Close(f)
+
+		log.Info().Msgf("Loading image index from %s", cfg.File)
+
+		return NewBulkIndex(f, client.languages)
+	}
+
+	bd, err := client.FindBulkData(ctx, cfg.BulkTypeOrDefault())
+	if err != nil {
+		return nil, err
+	}
+	url, err := client.cfg.EnsureBaseURL(bd.DownloadURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bulk data url %s, %w", bd.DownloadURI, err)
+	}
+
+	log.Info().Msgf("Loading image index from %s", url)
+	opts := web.NewGetOpts().
+		WithHeader(web.HeaderAccept, web.MimeTypeJSON).
+		WithExpectedCodes(200)
+	resp, err := download.Get(ctx, url, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download bulk data %s due to %w", url, err)
+	}
+	defer aio.Close(resp.Body)
+
+	return NewBulkIndex(resp.Body, client.languages)
+}
@@ -0,0 +1,157 @@
+package scryfall_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/scryfall"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBulkIndex(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "bulk", "images.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	idx, err := scryfall.NewBulkIndex(f, scryfall.DefaultLanguages)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, idx.Len())
+	cases := []struct {
+		name    string
+		setCode string
+		number  string
+		lang    string
+		want    *scryfall.Card
+	}{
+		{
+			name:    "single face",
+			setCode: "10E",
+			number:  "2",
+			lang:    "deu",
+			want: &scryfall.Card{
+				Name:            "Indexed",
+				Lang:            "de",
+				Set:             "10e",
+				CollectorNumber: "2",
+				ImgUris:         scryfall.ImgURIs{Normal: "images/cardImage.jpg"},
+				Faces:           []scryfall.CardFace{},
+			},
+		},
+		{
+			name:    "multiple faces",
+			setCode: "10e",
+			number:  "6",
+			lang:    "eng",
+			want: &scryfall.Card{
+				Name:            "Front // Back",
+				Lang:            "en",
+				Set:             "10e",
+				CollectorNumber: "6",
+				Faces: []scryfall.CardFace{
+					{Name: "Front", ImgUris: scryfall.ImgURIs{Normal: "images/front.jpg"}},
+					{Name: "Back", ImgUris: scryfall.ImgURIs{Normal: "images/back.jpg"}},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ok := idx.Find(tc.setCode, tc.number, tc.lang)
+
+			require.True(t, ok)
+			assert.Equal(t, tc.want, c)
+		})
+	}
+
+	t.Run("unsupported language", func(t *testing.T) {
+		_, ok := idx.Find("10E", "2", "jpn")
+
+		assert.False(t, ok)
+	})
+}
+
+func TestLoadBulkIndex(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+
+	t.Run("download", func(t *testing.T) {
+		idx, err := scryfall.LoadBulkIndex(t.Context(), scryClient, wclient, config.ImageIndex{Enabled: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, idx.Len())
+	})
+
+	t.Run("local file", func(t *testing.T) {
+		cfg := config.ImageIndex{Enabled: true, File: filepath.Join("testdata", "bulk", "images.json")}
+
+		idx, err := scryfall.LoadBulkIndex(t.Context(), scryClient, wclient, cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, idx.Len())
+	})
+
+	t.Run("unknown bulk type", func(t *testing.T) {
+		_, err := scryfall.LoadBulkIndex(t.Context(), scryClient, wclient, config.ImageIndex{BulkType: "unknown"})
+
+		require.Error(t, err)
+	})
+}
+
+func TestGetImageWithIndex(t *testing.T) {
+	expectedImg, err := os.ReadFile(filepath.Join("testdata", "images", "cardImage.jpg"))
+	require.NoError(t, err)
+
+	var cardRequests int
+	fs := http.FileServer(http.Dir("testdata"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cards/") {
+			cardRequests++
+		}
+		fs.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	f, err := os.Open(filepath.Join("testdata", "bulk", "images.json"))
+	require.NoError(t, err)
+	defer f.Close()
+	idx, err := scryfall.NewBulkIndex(f, scryfall.DefaultLanguages)
+	require.NoError(t, err)
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages).WithIndex(idx)
+
+	t.Run("success", func(t *testing.T) {
+		r, err := scryClient.GetImage(t.Context(), cards.Filter{SetCode: "10E", Number: "2", Lang: "deu", Name: "Indexed"})
+
+		require.NoError(t, err)
+		b, err := io.ReadAll(r.File)
+		r.File.Close()
+		require.NoError(t, err)
+		assert.Equal(t, expectedImg, b)
+	})
+
+	t.Run("not part of the index", func(t *testing.T) {
+		// exists in the cards api but not in the index
+		_, err := scryClient.GetImage(t.Context(), cards.Filter{SetCode: "10E", Number: "1", Lang: "deu", Name: "First"})
+
+		require.ErrorIs(t, err, cards.ErrCardNotFound)
+		assert.ErrorIs(t, err, cards.ErrImageNotFound)
+	})
+
+	assert.Equal(t, 0, cardRequests)
+}
@@ -9,6 +9,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/konstantinfoerster/card-importer-go/internal/aio"
 	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 	"github.com/konstantinfoerster/card-importer-go/internal/config"
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
@@ -31,6 +32,15 @@ type Client struct {
 	cfg       config.Scryfall
 	wclient   web.Client
 	languages cards.LanguageMapper
+	index     *BulkIndex
+}
+
+// WithIndex Uses the given index to look up image urls instead of requesting every card. Cards that are not part
+// of the index are treated as not found.
+func (c *Client) WithIndex(index *BulkIndex) *Client {
+	c.index = index
+
+	return c
 }
 
 func (c *Client) FindCard(ctx context.Context, setCode, number, lang string) (*Card, error) {
@@ -43,7 +53,7 @@ func (c *Client) FindCard(ctx context.Context, setCode, number, lang string) (*C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create get card url due to invalid url due to %w", err)
 	}
-	url += "?format=json&version=normal"
+	url += "?format=json"
 
 	opts := web.NewGetOpts().
 		WithHeader(web.HeaderAccept, web.MimeTypeJSON).
@@ -56,6 +66,7 @@ func (c *Client) FindCard(ctx context.Context, setCode, number, lang string) (*C
 
 		return nil, fmt.Errorf("failed to find card %s due to %w", url, err)
 	}
+	defer aio.Close(resp.Body)
 
 	var sc Card
 	if err := json.NewDecoder(resp.Body).Decode(&sc); err != nil {
@@ -65,25 +76,128 @@ func (c *Client) FindCard(ctx context.Context, setCode, number, lang string) (*C
 	return &sc, nil
 }
 
+// FindBulkData Returns the description of the bulk data file with the given type e.g. default_cards.
+func (c *Client) FindBulkData(ctx context.Context, bulkType string) (*BulkData, error) {
+	url, err := c.cfg.EnsureBaseURL(path.Join("bulk-data", bulkType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk data url due to invalid url due to %w", err)
+	}
+
+	opts := web.NewGetOpts().
+		WithHeader(web.HeaderAccept, web.MimeTypeJSON).
+		WithExpectedCodes(200)
+	resp, err := c.wclient.Get(ctx, url, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bulk data %s due to %w", url, err)
+	}
+	defer aio.Close(resp.Body)
+
+	var bd BulkData
+	if err := json.NewDecoder(resp.Body).Decode(&bd); err != nil {
+		return nil, fmt.Errorf("failed to decode scryfall bulk data due to %w", err)
+	}
+	if bd.DownloadURI == "" {
+		return nil, fmt.Errorf("bulk data %s has no download uri", bulkType)
+	}
+
+	return &bd, nil
+}
+
+// FindSets Returns all sets, following the pagination of the sets endpoint.
+func (c *Client) FindSets(ctx context.Context) ([]Set, error) {
+	url, err := c.cfg.EnsureBaseURL("sets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sets url due to invalid url due to %w", err)
+	}
+
+	opts := web.NewGetOpts().
+		WithHeader(web.HeaderAccept, web.MimeTypeJSON).
+		WithExpectedCodes(200)
+
+	var sets []Set
+	for url != "" {
+		page, err := c.findSetPage(ctx, url, opts)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, page.Data...)
+
+		url = ""
+		if page.HasMore {
+			url = page.NextPage
+		}
+	}
+
+	return sets, nil
+}
+
+func (c *Client) findSetPage(ctx context.Context, url string, opts web.GetOptions) (*setList, error) {
+	resp, err := c.wclient.Get(ctx, url, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sets %s due to %w", url, err)
+	}
+	defer aio.Close(resp.Body)
+
+	var page setList
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode scryfall sets due to %w", err)
+	}
+
+	return &page, nil
+}
+
 func (c *Client) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResult, error) {
-	sCard, err := c.FindCard(ctx, f.SetCode, f.Number, f.Lang)
+	return c.getImage(ctx, f, cards.Validators{})
+}
+
+// GetImageIfModified Returns the image only if it differs from the image with the given validators,
+// cards.ErrImageNotModified is returned otherwise.
+func (c *Client) GetImageIfModified(ctx context.Context, f cards.Filter, v cards.Validators) (*cards.ImageResult,
+	error) {
+	return c.getImage(ctx, f, v)
+}
+
+func (c *Client) getImage(ctx context.Context, f cards.Filter, v cards.Validators) (*cards.ImageResult, error) {
+	sCard, err := c.findImageCard(ctx, f)
 	if err != nil {
 		return nil, errors.Join(cards.ErrImageNotFound, err)
 	}
 
-	imgURLRaw := sCard.FindURL(f.Name)
+	targetLang, err := c.languages.Get(f.Lang)
+	if err != nil {
+		return nil, fmt.Errorf("language %s not found due to %w", f.Lang, err)
+	}
+	verified, err := sCard.Verify(f.SetCode, f.Number, targetLang)
+	if err != nil {
+		return nil, fmt.Errorf("scryfall card with set %s, number %s and language %s rejected due to %w", f.SetCode,
+			f.Number, f.Lang, err)
+	}
+
+	variant := f.Variant
+	if variant == "" {
+		variant = cards.VariantNormal
+	}
+	imgURLRaw := sCard.FindURL(f.Name, variant)
 	if imgURLRaw == "" {
-		return nil, fmt.Errorf("no matching scryfall card image with set %s, name %s, number %s and "+
-			"language %s found due to %w", f.SetCode, f.Name, f.Number, f.Lang, cards.ErrImageNotFound)
+		return nil, fmt.Errorf("no matching scryfall card image with set %s, name %s, number %s, "+
+			"language %s and variant %s found due to %w", f.SetCode, f.Name, f.Number, f.Lang, variant,
+			cards.ErrImageNotFound)
 	}
 	imgURL, err := c.cfg.EnsureBaseURL(imgURLRaw)
 	if err != nil {
 		return nil, fmt.Errorf("invalid scryfall card image url %s, %w", imgURL, errors.Join(err, cards.ErrImageNotFound))
 	}
 
+	accept := web.MimeTypeJpeg
+	if variant == cards.VariantPng {
+		accept = web.MimeTypePng
+	}
 	opts := web.NewGetOpts().
-		WithHeader(web.HeaderAccept, web.MimeTypeJpeg).
+		WithHeader(web.HeaderAccept, accept).
 		WithExpectedCodes(200)
+	if v != (cards.Validators{}) {
+		opts = opts.WithConditional(v.ETag, v.LastModified)
+	}
 	resp, err := c.wclient.Get(ctx, imgURL, opts)
 	if err != nil {
 		if web.IsStatusCode(err, http.StatusNotFound) {
@@ -92,9 +206,110 @@ func (c *Client) GetImage(ctx context.Context, f cards.Filter) (*cards.ImageResu
 
 		return nil, fmt.Errorf("failed to get image from %s due to %w", imgURL, err)
 	}
+	if resp.NotModified {
+		aio.Close(resp.Body)
+
+		return nil, fmt.Errorf("image %s %w", imgURL, cards.ErrImageNotModified)
+	}
+
+	return &cards.ImageResult{
+		MimeType:     resp.MimeType,
+		File:         resp.Body,
+		ETag:         resp.ETag,
+		LastModified: resp.LastModified,
+		Verified:     verified,
+	}, nil
+}
+
+func (c *Client) findImageCard(ctx context.Context, f cards.Filter) (*Card, error) {
+	if c.index == nil {
+		return c.FindCard(ctx, f.SetCode, f.Number, f.Lang)
+	}
+
+	sCard, ok := c.index.Find(f.SetCode, f.Number, f.Lang)
+	if !ok {
+		return nil, fmt.Errorf("card with set %s, number %s and language %s is not part of the image index %w",
+			f.SetCode, f.Number, f.Lang, cards.ErrCardNotFound)
+	}
+
+	return sCard, nil
+}
+
+// FindSetIcons Returns the icon url of every set.
+func (c *Client) FindSetIcons(ctx context.Context) ([]cards.SymbolSource, error) {
+	sets, err := c.FindSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	icons := make([]cards.SymbolSource, 0, len(sets))
+	for _, s := range sets {
+		icons = append(icons, cards.SymbolSource{
+			Code: strings.ToUpper(s.Code),
+			Name: s.Name,
+			URL:  s.IconSvgURI,
+		})
+	}
+
+	return icons, nil
+}
+
+// FindSymbols Returns the image url of every mana and cost symbol.
+func (c *Client) FindSymbols(ctx context.Context) ([]cards.SymbolSource, error) {
+	url, err := c.cfg.EnsureBaseURL("symbology")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create symbology url due to invalid url due to %w", err)
+	}
+
+	opts := web.NewGetOpts().
+		WithHeader(web.HeaderAccept, web.MimeTypeJSON).
+		WithExpectedCodes(200)
+	resp, err := c.wclient.Get(ctx, url, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find symbols %s due to %w", url, err)
+	}
+	defer aio.Close(resp.Body)
+
+	var list symbolList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode scryfall symbols due to %w", err)
+	}
+
+	symbols := make([]cards.SymbolSource, 0, len(list.Data))
+	for _, s := range list.Data {
+		symbols = append(symbols, cards.SymbolSource{
+			Code: s.Symbol,
+			Name: s.English,
+			URL:  s.SvgURI,
+		})
+	}
+
+	return symbols, nil
+}
+
+// GetSymbolImage Returns the svg image of a set icon or symbol.
+func (c *Client) GetSymbolImage(ctx context.Context, rawURL string) (*cards.ImageResult, error) {
+	url, err := c.cfg.EnsureBaseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scryfall symbol url %s, %w", rawURL, errors.Join(err, cards.ErrImageNotFound))
+	}
+
+	opts := web.NewGetOpts().
+		WithHeader(web.HeaderAccept, web.MimeTypeSvg).
+		WithExpectedCodes(200)
+	resp, err := c.wclient.Get(ctx, url, opts)
+	if err != nil {
+		if web.IsStatusCode(err, http.StatusNotFound) {
+			err = errors.Join(err, cards.ErrImageNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to get symbol image from %s due to %w", url, err)
+	}
 
 	return &cards.ImageResult{
-		MimeType: resp.MimeType,
-		File:     resp.Body,
+		MimeType:     resp.MimeType,
+		File:         resp.Body,
+		ETag:         resp.ETag,
+		LastModified: resp.LastModified,
 	}, nil
 }
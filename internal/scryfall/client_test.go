@@ -26,7 +26,10 @@ func TestFindCard(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		expected := &scryfall.Card{
-			Name: "First",
+			Name:            "First",
+			Lang:            "de",
+			Set:             "10e",
+			CollectorNumber: "1",
 			ImgUris: scryfall.ImgURIs{
 				Normal: "images/cardImage.jpg",
 			},
@@ -73,5 +76,155 @@ func TestGetImage(t *testing.T) {
 		b, err := io.ReadAll(r.File)
 		require.NoError(t, err)
 		assert.Equal(t, expectedImg, b)
+		assert.NotEmpty(t, r.LastModified)
+		assert.True(t, r.Verified)
+	})
+
+	t.Run("other language rejected", func(t *testing.T) {
+		f := cards.Filter{SetCode: "10e", Number: "2", Lang: "deu", Name: "Second"}
+
+		_, err := scryClient.GetImage(t.Context(), f)
+
+		var mismatch *cards.MismatchError
+		require.ErrorAs(t, err, &mismatch)
+		assert.Equal(t, "lang de != en", mismatch.Mismatch)
+		require.ErrorIs(t, err, cards.ErrImageNotFound)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		f := cards.Filter{SetCode: "10e", Number: "1", Lang: "deu", Name: "First"}
+		r, err := scryClient.GetImage(t.Context(), f)
+		require.NoError(t, err)
+		r.File.Close()
+
+		_, err = scryClient.GetImageIfModified(t.Context(), f, cards.Validators{LastModified: r.LastModified})
+
+		require.ErrorIs(t, err, cards.ErrImageNotModified)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		f := cards.Filter{SetCode: "10e", Number: "1", Lang: "deu", Name: "First"}
+		v := cards.Validators{LastModified: "Wed, 21 Oct 2015 07:28:00 GMT"}
+
+		r, err := scryClient.GetImageIfModified(t.Context(), f, v)
+
+		require.NoError(t, err)
+		b, err := io.ReadAll(r.File)
+		require.NoError(t, err)
+		assert.Equal(t, expectedImg, b)
+	})
+}
+
+func TestFindBulkData(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+
+	t.Run("success", func(t *testing.T) {
+		bd, err := scryClient.FindBulkData(t.Context(), "default_cards")
+
+		require.NoError(t, err)
+		assert.Equal(t, "default_cards", bd.Type)
+		assert.Equal(t, "https://data.scryfall.io/default-cards/default-cards-20240101100000.json", bd.DownloadURI)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := scryClient.FindBulkData(t.Context(), "unknown")
+
+		var apiErr *web.ExternalAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestFindSets(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+	want := []scryfall.Set{
+		{
+			Code:       "10e",
+			Name:       "Tenth Edition",
+			SetType:    "core",
+			ReleasedAt: "2007-07-13",
+			Block:      "Core Set",
+			CardCount:  383,
+			IconSvgURI: "/symbols/sets/10e.svg",
+		},
+	}
+
+	sets, err := scryClient.FindSets(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, want, sets)
+}
+
+func TestFindSetIcons(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+	want := []cards.SymbolSource{
+		{Code: "10E", Name: "Tenth Edition", URL: "/symbols/sets/10e.svg"},
+	}
+
+	icons, err := scryClient.FindSetIcons(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, want, icons)
+}
+
+func TestFindSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+	want := []cards.SymbolSource{
+		{Code: "{W}", Name: "one white mana", URL: "/symbols/card/W.svg"},
+		{Code: "{T}", Name: "tap this permanent", URL: "/symbols/card/T.svg"},
+	}
+
+	symbols, err := scryClient.FindSymbols(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, want, symbols)
+}
+
+func TestGetSymbolImage(t *testing.T) {
+	expectedImg, err := os.ReadFile(path.Join("testdata", "symbols", "card", "W.svg"))
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+	scryClient := scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+
+	t.Run("success", func(t *testing.T) {
+		r, err := scryClient.GetSymbolImage(t.Context(), "/symbols/card/W.svg")
+
+		require.NoError(t, err)
+		defer r.File.Close()
+		b, err := io.ReadAll(r.File)
+		require.NoError(t, err)
+		assert.Equal(t, expectedImg, b)
+		assert.Equal(t, web.MimeTypeSvg, r.MimeType.Raw())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := scryClient.GetSymbolImage(t.Context(), "/symbols/card/T.svg")
+
+		require.ErrorIs(t, err, cards.ErrImageNotFound)
 	})
 }
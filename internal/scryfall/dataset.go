@@ -0,0 +1,355 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// supertypes All known supertypes, every other type in front of the em-dash is a card type.
+var supertypes = []string{"Basic", "Elite", "Host", "Legendary", "Ongoing", "Snow", "World"}
+
+// layouts Scryfall layouts that are named differently or are not known by the card model.
+var layouts = map[string]string{
+	"double_faced_token": "TOKEN",
+	"emblem":             "TOKEN",
+	"battle":             "TRANSFORM",
+}
+
+// unsupportedLayouts Layouts that are not cards e.g. art series.
+var unsupportedLayouts = []string{"art_series"}
+
+type scryfallDataset struct {
+	client      *Client
+	setService  cards.Service[*cards.CardSet]
+	cardService cards.Service[*cards.Card]
+	languages   cards.LanguageMapper
+}
+
+// NewDataset Creates a dataset that imports a Scryfall bulk data file e.g. default_cards or all_cards.
+// The set details are loaded from the Scryfall sets endpoint.
+func NewDataset(client *Client, setService cards.Service[*cards.CardSet],
+	cardService cards.Service[*cards.Card]) cards.Dataset {
+	return &scryfallDataset{
+		client:      client,
+		setService:  setService,
+		cardService: cardService,
+		languages:   DefaultLanguages,
+	}
+}
+
+// Import Imports all cards of supported languages. The bulk data file contains one entry per card and language,
+// therefore all cards are collected before they are imported, so each card is imported with all of its translations.
+func (imp *scryfallDataset) Import(r io.Reader) (*cards.Report, error) {
+	errg, ctx := errgroup.WithContext(context.Background())
+
+	knownSets, err := imp.client.FindSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setsByCode := make(map[string]Set, len(knownSets))
+	for _, s := range knownSets {
+		setsByCode[strings.ToUpper(strings.TrimSpace(s.Code))] = s
+	}
+
+	importedSets := map[string]bool{}
+	cc := &cardCollector{entries: map[string]*collectedCard{}}
+	for r := range parseBulk(ctx, r) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		bc := r.Result
+		lang, ok := supportedLang(*bc, imp.languages)
+		if !ok {
+			continue
+		}
+
+		setCode := strings.ToUpper(strings.TrimSpace(bc.Set))
+		if !importedSets[setCode] {
+			set := mapToCardSet(*bc, setsByCode[setCode])
+			if err := imp.setService.Import(set); err != nil {
+				return nil, err
+			}
+			importedSets[setCode] = true
+			log.Info().Msgf("Finished set %s", set.Code)
+		}
+
+		cc.Add(*bc, lang)
+	}
+
+	for _, entry := range cc.Cards() {
+		errg.Go(func() error {
+			if err := imp.cardService.Import(entry); err != nil {
+				return err
+			}
+			if e := log.Trace(); e.Enabled() {
+				e.Msgf("Finished card %s from set %s", entry.Number, entry.CardSetCode)
+			}
+
+			return nil
+		})
+	}
+
+	if err := errg.Wait(); err != nil {
+		return nil, err
+	}
+
+	cardCount, err := imp.cardService.Count()
+	if err != nil {
+		return nil, err
+	}
+	setCount, err := imp.setService.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cards.Report{
+		CardCount: cardCount,
+		SetCount:  setCount,
+	}, nil
+}
+
+// ReadCards Reads all cards of supported languages from a bulk data file without importing them.
+func ReadCards(r io.Reader) ([]*cards.Card, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cc := &cardCollector{entries: map[string]*collectedCard{}}
+	for r := range parseBulk(ctx, r) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		lang, ok := supportedLang(*r.Result, DefaultLanguages)
+		if !ok {
+			continue
+		}
+		cc.Add(*r.Result, lang)
+	}
+
+	return cc.Cards(), nil
+}
+
+// supportedLang Returns the mapped language of the entry and false if the language or layout is not supported.
+func supportedLang(bc BulkCard, languages cards.LanguageMapper) (string, bool) {
+	lang := languages.ByExternal(bc.Lang)
+	if lang == "" || slices.Contains(unsupportedLayouts, strings.ToLower(bc.Layout)) {
+		return "", false
+	}
+
+	return lang, true
+}
+
+type collectedCard struct {
+	card *cards.Card
+	// true if the card data comes from the english entry
+	isBase       bool
+	translations map[int][]cards.FaceTranslation
+}
+
+// cardCollector Merges the entries of all languages of a card into one card, identified by set code and number.
+type cardCollector struct {
+	entries map[string]*collectedCard
+	keys    []string
+}
+
+// Add Adds the entry of the given language. The english entry is used as card data, other languages are added
+// as translations. The card data of a translated entry is only used until the english entry is found.
+func (c *cardCollector) Add(bc BulkCard, lang string) {
+	key := fmt.Sprintf("%s_%s", strings.ToUpper(strings.TrimSpace(bc.Set)), strings.TrimSpace(bc.CollectorNumber))
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &collectedCard{translations: map[int][]cards.FaceTranslation{}}
+		c.entries[key] = entry
+		c.keys = append(c.keys, key)
+	}
+
+	isBase := strings.EqualFold(strings.TrimSpace(bc.Lang), "en")
+	if entry.card == nil || (isBase && !entry.isBase) {
+		entry.card = mapToCard(bc, isBase)
+		entry.isBase = isBase
+	}
+
+	if !isBase {
+		for i, t := range mapToTranslations(bc, lang) {
+			entry.translations[i] = append(entry.translations[i], t)
+		}
+	}
+}
+
+// Cards Returns all collected cards in the order they were found.
+func (c *cardCollector) Cards() []*cards.Card {
+	result := make([]*cards.Card, 0, len(c.keys))
+	for _, key := range c.keys {
+		entry := c.entries[key]
+		for i, f := range entry.card.Faces {
+			// faces without translated entries keep the existing translations
+			tt, ok := entry.translations[i]
+			f.Translations = tt
+			f.KeepTranslations = !ok
+		}
+		result = append(result, entry.card)
+	}
+
+	return result
+}
+
+func mapToCardSet(bc BulkCard, s Set) *cards.CardSet {
+	if s.Code == "" {
+		// set is unknown, fallback to the set details of the card
+		s = Set{Code: bc.Set, Name: bc.SetName, SetType: bc.SetType, ReleasedAt: bc.ReleasedAt}
+	}
+
+	released, err := time.Parse("2006-01-02", strings.TrimSpace(s.ReleasedAt)) // ISO 8601 YYYY-MM-DD
+	if err != nil {
+		released = time.Time{}
+	}
+
+	return &cards.CardSet{
+		Code:       strings.ToUpper(strings.TrimSpace(s.Code)),
+		Name:       strings.TrimSpace(s.Name),
+		TotalCount: s.CardCount,
+		Released:   released,
+		Block:      cards.CardBlock{Block: strings.TrimSpace(s.Block)},
+		Type:       strings.ToUpper(strings.TrimSpace(s.SetType)),
+		// the set data of Scryfall doesn't contain translations
+		KeepTranslations: true,
+	}
+}
+
+// mapToCard Maps the card without translations. The flavor text and multiverse id are only used if the entry is
+// english, translated entries contain the values of the translated print.
+func mapToCard(bc BulkCard, isBase bool) *cards.Card {
+	layout := strings.ToLower(strings.TrimSpace(bc.Layout))
+	mappedLayout, ok := layouts[layout]
+	if !ok {
+		mappedLayout = strings.ToUpper(layout)
+	}
+
+	faces := bc.Faces
+	if len(faces) == 0 || layout == "meld" {
+		single := bc.BulkCardFace
+		single.Name = bc.Name
+		faces = []BulkCardFace{single}
+	}
+
+	var result []*cards.Face
+	for i, f := range faces {
+		cmc := bc.Cmc
+		if f.Cmc != nil {
+			cmc = f.Cmc
+		}
+		colors := f.Colors
+		if colors == nil {
+			colors = bc.Colors
+		}
+		artist := f.Artist
+		if artist == "" {
+			artist = bc.Artist
+		}
+		multiverseID := 0
+		flavorText := ""
+		if isBase {
+			if i < len(bc.MultiverseIDs) {
+				multiverseID = bc.MultiverseIDs[i]
+			}
+			flavorText = strings.TrimSpace(f.FlavorText)
+		}
+
+		face := &cards.Face{
+			Name:         strings.TrimSpace(f.Name),
+			Artist:       strings.TrimSpace(artist),
+			Colors:       cards.NewColors(colors),
+			Text:         strings.TrimSpace(f.OracleText),
+			FlavorText:   flavorText,
+			HandModifier: strings.TrimSpace(f.HandModifier),
+			LifeModifier: strings.TrimSpace(f.LifeModifier),
+			Loyalty:      strings.TrimSpace(f.Loyalty),
+			ManaCost:     strings.TrimSpace(f.ManaCost),
+			Power:        strings.TrimSpace(f.Power),
+			Toughness:    strings.TrimSpace(f.Toughness),
+			MultiverseID: multiverseID,
+			TypeLine:     strings.TrimSpace(f.TypeLine),
+		}
+		if cmc != nil {
+			face.ConvertedManaCost = *cmc
+		}
+		face.Supertypes, face.Cardtypes, face.Subtypes = splitTypeLine(face.TypeLine)
+		result = append(result, face)
+	}
+
+	return &cards.Card{
+		Name:        strings.TrimSpace(bc.Name),
+		CardSetCode: strings.ToUpper(strings.TrimSpace(bc.Set)),
+		Number:      strings.TrimSpace(bc.CollectorNumber),
+		Border:      strings.ToUpper(strings.TrimSpace(bc.BorderColor)),
+		Rarity:      strings.ToUpper(strings.TrimSpace(bc.Rarity)),
+		Layout:      mappedLayout,
+		Faces:       result,
+	}
+}
+
+// mapToTranslations Returns the translations of a translated entry keyed by the face position.
+func mapToTranslations(bc BulkCard, lang string) map[int]cards.FaceTranslation {
+	faces := bc.Faces
+	if len(faces) == 0 || strings.ToLower(strings.TrimSpace(bc.Layout)) == "meld" {
+		faces = []BulkCardFace{bc.BulkCardFace}
+	}
+
+	result := map[int]cards.FaceTranslation{}
+	for i, f := range faces {
+		multiverseID := 0
+		if i < len(bc.MultiverseIDs) {
+			multiverseID = bc.MultiverseIDs[i]
+		}
+
+		t := cards.FaceTranslation{
+			Name:         strings.TrimSpace(f.PrintedName),
+			Text:         strings.TrimSpace(f.PrintedText),
+			FlavorText:   strings.TrimSpace(f.FlavorText),
+			TypeLine:     strings.TrimSpace(f.PrintedTypeLine),
+			MultiverseID: multiverseID,
+			Lang:         lang,
+		}
+		if t.Name != "" {
+			result[i] = t
+		}
+	}
+
+	return result
+}
+
+// splitTypeLine Splits a type line like 'Legendary Creature — Human Wizard' into super, card and sub types.
+func splitTypeLine(typeLine string) ([]string, []string, []string) {
+	left, right, _ := strings.Cut(typeLine, "—")
+
+	var superTypes, cardTypes, subTypes []string
+	for _, t := range strings.Fields(left) {
+		if slices.Contains(supertypes, t) {
+			superTypes = append(superTypes, t)
+
+			continue
+		}
+		cardTypes = append(cardTypes, t)
+	}
+
+	right = strings.TrimSpace(right)
+	if right == "" {
+		return superTypes, cardTypes, subTypes
+	}
+	if slices.Contains(cardTypes, "Plane") {
+		// planar types can contain spaces e.g. 'New Phyrexia'
+		return superTypes, cardTypes, []string{right}
+	}
+	subTypes = strings.Fields(right)
+
+	return superTypes, cardTypes, subTypes
+}
@@ -0,0 +1,53 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type result struct {
+	Result *BulkCard
+	Err    error
+}
+
+// parseBulk streams the cards of a bulk data file. The file contains a single array with all cards.
+func parseBulk(ctx context.Context, r io.Reader) <-chan result {
+	c := make(chan result)
+
+	go func() {
+		defer close(c)
+
+		dec := json.NewDecoder(r)
+
+		t, err := dec.Token()
+		if err != nil {
+			c <- result{Result: nil, Err: fmt.Errorf("failed to get next token %w", err)}
+
+			return
+		}
+		if t != json.Delim('[') {
+			c <- result{Result: nil, Err: fmt.Errorf("expected token to be %v but found %v", json.Delim('['), t)}
+
+			return
+		}
+
+		for dec.More() {
+			var card BulkCard
+			if err := dec.Decode(&card); err != nil {
+				c <- result{Result: nil, Err: fmt.Errorf("failed to decode bulk card %w", err)}
+
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case c <- result{Result: &card, Err: nil}:
+			}
+		}
+	}()
+
+	return c
+}
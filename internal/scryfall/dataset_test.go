@@ -0,0 +1,235 @@
+package scryfall_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
+	"github.com/konstantinfoerster/card-importer-go/internal/config"
+	"github.com/konstantinfoerster/card-importer-go/internal/scryfall"
+	"github.com/konstantinfoerster/card-importer-go/internal/test"
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSetService struct {
+	Sets []cards.CardSet
+}
+
+func (s *MockSetService) Import(set *cards.CardSet) error {
+	s.Sets = append(s.Sets, *set)
+
+	return nil
+}
+
+func (s *MockSetService) Count() (int, error) {
+	return len(s.Sets), nil
+}
+
+type MockCardService struct {
+	mu    sync.Mutex
+	Cards []cards.Card
+}
+
+func (s *MockCardService) Import(card *cards.Card) error {
+	// will be called concurrently from the importer
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Cards = append(s.Cards, *card)
+
+	return nil
+}
+
+func (s *MockCardService) Count() (int, error) {
+	return len(s.Cards), nil
+}
+
+func (s *MockCardService) CardsOrdered() []cards.Card {
+	sort.SliceStable(s.Cards, func(i, j int) bool {
+		return s.Cards[i].CardSetCode+s.Cards[i].Number < s.Cards[j].CardSetCode+s.Cards[j].Number
+	})
+
+	return s.Cards
+}
+
+func newTestClient(t *testing.T) *scryfall.Client {
+	t.Helper()
+
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(ts.Close)
+
+	cfg := config.Scryfall{BaseURL: ts.URL}
+	wclient := web.NewClient(cfg.Client, &http.Client{})
+
+	return scryfall.NewClient(cfg, wclient, scryfall.DefaultLanguages)
+}
+
+func TestImportBulkData(t *testing.T) {
+	setService := &MockSetService{}
+	cardService := &MockCardService{}
+	dataset := scryfall.NewDataset(newTestClient(t), setService, cardService)
+	wantSets := []cards.CardSet{
+		{
+			Code:             "10E",
+			Name:             "Tenth Edition",
+			TotalCount:       383,
+			Released:         time.Date(2007, time.July, 13, 0, 0, 0, 0, time.UTC),
+			Block:            cards.CardBlock{Block: "Core Set"},
+			Type:             "CORE",
+			KeepTranslations: true,
+		},
+		{
+			Code:             "NEW",
+			Name:             "New Set",
+			Released:         time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Type:             "EXPANSION",
+			KeepTranslations: true,
+		},
+	}
+	wantCards := []cards.Card{
+		{
+			CardSetCode: "10E",
+			Number:      "4",
+			Name:        "Benalish Hero",
+			Rarity:      "COMMON",
+			Layout:      "NORMAL",
+			Border:      "WHITE",
+			Faces: []*cards.Face{
+				{
+					Name:              "Benalish Hero",
+					Artist:            "Douglas Shuler",
+					ConvertedManaCost: 1.0,
+					Colors:            cards.NewColors([]string{"W"}),
+					Text:              "Banding",
+					FlavorText:        "English Flavor",
+					ManaCost:          "{W}",
+					Power:             "1",
+					Toughness:         "1",
+					MultiverseID:      831,
+					TypeLine:          "Creature — Human Soldier",
+					Cardtypes:         []string{"Creature"},
+					Subtypes:          []string{"Human", "Soldier"},
+					Translations: []cards.FaceTranslation{
+						{
+							Name:         "Benalischer Held",
+							Text:         "Verbünden",
+							FlavorText:   "Deutscher Flavor",
+							TypeLine:     "Kreatur — Mensch, Soldat",
+							MultiverseID: 150000,
+							Lang:         "deu",
+						},
+					},
+				},
+			},
+		},
+		{
+			CardSetCode: "10E",
+			Number:      "5",
+			Name:        "Fire // Ice",
+			Rarity:      "UNCOMMON",
+			Layout:      "SPLIT",
+			Border:      "BLACK",
+			Faces: []*cards.Face{
+				{
+					Name:              "Fire",
+					Artist:            "Franz Vohwinkel",
+					ConvertedManaCost: 4.0,
+					Colors:            cards.NewColors([]string{"R", "U"}),
+					Text:              "Fire deals 2 damage divided as you choose among one or two targets.",
+					ManaCost:          "{1}{R}",
+					TypeLine:          "Instant",
+					Cardtypes:         []string{"Instant"},
+					KeepTranslations:  true,
+				},
+				{
+					Name:              "Ice",
+					Artist:            "Franz Vohwinkel",
+					ConvertedManaCost: 4.0,
+					Colors:            cards.NewColors([]string{"R", "U"}),
+					Text:              "Tap target permanent.\nDraw a card.",
+					ManaCost:          "{1}{U}",
+					TypeLine:          "Instant",
+					Cardtypes:         []string{"Instant"},
+					KeepTranslations:  true,
+				},
+			},
+		},
+		{
+			CardSetCode: "NEW",
+			Number:      "1",
+			Name:        "Karn Liberated",
+			Rarity:      "MYTHIC",
+			Layout:      "NORMAL",
+			Border:      "BLACK",
+			Faces: []*cards.Face{
+				{
+					Name:              "Karn Liberated",
+					ConvertedManaCost: 7.0,
+					Colors:            cards.NewColors([]string{}),
+					ManaCost:          "{7}",
+					Loyalty:           "6",
+					TypeLine:          "Legendary Planeswalker — Karn",
+					Supertypes:        []string{"Legendary"},
+					Cardtypes:         []string{"Planeswalker"},
+					Subtypes:          []string{"Karn"},
+					KeepTranslations:  true,
+				},
+			},
+		},
+	}
+
+	report, err := dataset.Import(test.LoadFile(t, "testdata/bulk/cards.json"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.CardCount)
+	assert.Equal(t, 2, report.SetCount)
+	assert.Equal(t, wantSets, setService.Sets)
+	assert.Equal(t, wantCards, cardService.CardsOrdered())
+}
+
+func TestImportBulkDataWithPlaneTypes(t *testing.T) {
+	cardService := &MockCardService{}
+	dataset := scryfall.NewDataset(newTestClient(t), &MockSetService{}, cardService)
+	source := strings.NewReader(`[
+		{
+			"lang": "en", "name": "Norn's Dominion", "set": "10e", "collector_number": "10",
+			"rarity": "common", "border_color": "black", "layout": "planar",
+			"type_line": "Plane — New Phyrexia"
+		}
+	]`)
+
+	_, err := dataset.Import(source)
+
+	require.NoError(t, err)
+	require.Len(t, cardService.Cards, 1)
+	face := cardService.Cards[0].Faces[0]
+	assert.Equal(t, []string{"Plane"}, face.Cardtypes)
+	assert.Equal(t, []string{"New Phyrexia"}, face.Subtypes)
+	assert.Equal(t, "PLANAR", cardService.Cards[0].Layout)
+}
+
+func TestImportBulkDataInvalidContent(t *testing.T) {
+	dataset := scryfall.NewDataset(newTestClient(t), &MockSetService{}, &MockCardService{})
+
+	_, err := dataset.Import(strings.NewReader(`{}`))
+
+	assert.ErrorContains(t, err, "expected token to be [")
+}
+
+func TestReadCards(t *testing.T) {
+	got, err := scryfall.ReadCards(test.LoadFile(t, "testdata/bulk/cards.json"))
+
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "Benalish Hero", got[0].Name)
+	assert.Len(t, got[0].Faces[0].Translations, 1)
+	assert.Equal(t, "Fire // Ice", got[1].Name)
+	assert.Equal(t, "Karn Liberated", got[2].Name)
+}
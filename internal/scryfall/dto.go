@@ -1,18 +1,50 @@
 package scryfall
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 )
 
 type Card struct {
-	Name    string     `json:"name"`
-	ImgUris ImgURIs    `json:"image_uris"`
-	Faces   []CardFace `json:"card_faces"`
+	Name            string     `json:"name"`
+	Lang            string     `json:"lang"`
+	Set             string     `json:"set"`
+	CollectorNumber string     `json:"collector_number"`
+	ImgUris         ImgURIs    `json:"image_uris"`
+	Faces           []CardFace `json:"card_faces"`
 }
 
 type ImgURIs struct {
-	Normal string `json:"normal"`
+	Small      string `json:"small"`
+	Normal     string `json:"normal"`
+	Large      string `json:"large"`
+	Png        string `json:"png"`
+	ArtCrop    string `json:"art_crop"`
+	BorderCrop string `json:"border_crop"`
+}
+
+// Get Returns the url of the given variant or an empty string if the variant is unknown.
+func (u ImgURIs) Get(variant string) string {
+	switch variant {
+	case cards.VariantSmall:
+		return u.Small
+	case cards.VariantNormal:
+		return u.Normal
+	case cards.VariantLarge:
+		return u.Large
+	case cards.VariantPng:
+		return u.Png
+	case cards.VariantArtCrop:
+		return u.ArtCrop
+	case cards.VariantBorderCrop:
+		return u.BorderCrop
+	default:
+		return ""
+	}
 }
+
 type CardFace struct {
 	Name    string  `json:"name"`
 	ImgUris ImgURIs `json:"image_uris"`
@@ -23,17 +55,45 @@ type MatchingFace struct {
 	ID  int64
 }
 
-func (sc Card) FindURL(name string) string {
+// FindURL Returns the image url of the given variant of the face with the given name, or the url of the card if
+// no face matches.
+func (sc Card) FindURL(name, variant string) string {
 	for _, f := range sc.Faces {
-		if strings.EqualFold(f.Name, name) && f.ImgUris.Normal != "" {
-			return f.ImgUris.Normal
+		if u := f.ImgUris.Get(variant); strings.EqualFold(f.Name, name) && u != "" {
+			return u
 		}
 	}
 
 	// fallback to top img
-	if sc.ImgUris.Normal != "" {
-		return sc.ImgUris.Normal
+	return sc.ImgUris.Get(variant)
+}
+
+// Verify Compares the language, set and number of the card with the requested values, the language is the external
+// language e.g. de. A cards.MismatchError is returned if a value differs. The card is verified if all values are
+// present and equal, values the card doesn't provide can't be verified.
+func (sc Card) Verify(setCode, number, lang string) (bool, error) {
+	var mismatches []string
+	verified := true
+	for _, v := range []struct {
+		field, want, got string
+	}{
+		{field: "lang", want: lang, got: sc.Lang},
+		{field: "set", want: setCode, got: sc.Set},
+		{field: "collector_number", want: number, got: sc.CollectorNumber},
+	} {
+		if v.got == "" {
+			verified = false
+
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(v.want), strings.TrimSpace(v.got)) {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s != %s", v.field, v.want, v.got))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return false, &cards.MismatchError{Mismatch: strings.Join(mismatches, ", ")}
 	}
 
-	return ""
+	return verified, nil
 }
@@ -3,8 +3,10 @@ package scryfall_test
 import (
 	"testing"
 
+	"github.com/konstantinfoerster/card-importer-go/internal/cards"
 	"github.com/konstantinfoerster/card-importer-go/internal/scryfall"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFindURL(t *testing.T) {
@@ -99,9 +101,87 @@ func TestFindURL(t *testing.T) {
 	for i := range cases {
 		tc := cases[i]
 		t.Run(tc.name, func(t *testing.T) {
-			url := tc.card.FindURL(tc.searchTerm)
+			url := tc.card.FindURL(tc.searchTerm, cards.VariantNormal)
 
 			assert.Equal(t, tc.want, url)
 		})
 	}
 }
+
+func TestFindURLVariant(t *testing.T) {
+	card := scryfall.Card{
+		Name: "First // Second",
+		ImgUris: scryfall.ImgURIs{
+			Normal:  "http://localhost/normal",
+			ArtCrop: "http://localhost/art_crop",
+		},
+		Faces: []scryfall.CardFace{
+			{
+				Name: "Second",
+				ImgUris: scryfall.ImgURIs{
+					Small: "http://localhost/second/small",
+					Png:   "http://localhost/second/png",
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name       string
+		searchTerm string
+		variant    string
+		want       string
+	}{
+		{name: "face variant", searchTerm: "Second", variant: cards.VariantSmall, want: "http://localhost/second/small"},
+		{name: "png variant", searchTerm: "Second", variant: cards.VariantPng, want: "http://localhost/second/png"},
+		{name: "fallback top card variant", searchTerm: "Second", variant: cards.VariantArtCrop,
+			want: "http://localhost/art_crop"},
+		{name: "variant missing", searchTerm: "Second", variant: cards.VariantLarge, want: ""},
+		{name: "unknown variant", searchTerm: "Second", variant: "unknown", want: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, card.FindURL(tc.searchTerm, tc.variant))
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	card := scryfall.Card{Name: "First", Lang: "de", Set: "10e", CollectorNumber: "1a"}
+
+	cases := []struct {
+		name         string
+		card         scryfall.Card
+		setCode      string
+		number       string
+		lang         string
+		wantVerified bool
+		wantMismatch string
+	}{
+		{name: "all fields match", card: card, setCode: "10E", number: "1A", lang: "de", wantVerified: true},
+		{name: "fields missing", card: scryfall.Card{Name: "First", Lang: "de"}, setCode: "10E", number: "1A",
+			lang: "de"},
+		{name: "other language", card: card, setCode: "10E", number: "1A", lang: "en",
+			wantMismatch: "lang en != de"},
+		{name: "other printing", card: card, setCode: "9E", number: "2", lang: "de",
+			wantMismatch: "set 9E != 10e, collector_number 2 != 1a"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			verified, err := tc.card.Verify(tc.setCode, tc.number, tc.lang)
+
+			assert.Equal(t, tc.wantVerified, verified)
+			if tc.wantMismatch == "" {
+				require.NoError(t, err)
+
+				return
+			}
+			var mismatch *cards.MismatchError
+			require.ErrorAs(t, err, &mismatch)
+			assert.Equal(t, tc.wantMismatch, mismatch.Mismatch)
+			assert.ErrorIs(t, err, cards.ErrImageNotFound)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +15,9 @@ import (
 type Storer interface {
 	Store(in io.Reader, path ...string) (StoredFile, error)
 	Load(path ...string) (io.ReadCloser, error)
+	Delete(path ...string) error
+	// Walk Calls fn for every stored file with the path relative to the storage location and the file size.
+	Walk(fn func(path string, size int64) error) error
 }
 
 type StoredFile struct {
@@ -58,6 +62,8 @@ func (s *localStorage) fromBasePath(path ...string) (string, error) {
 	return targetDir, nil
 }
 
+// Store Writes the content into a temporary file that is moved to the target path once the content is completely
+// written, so a failed or interrupted write never leaves a partial file behind.
 func (s *localStorage) Store(r io.Reader, path ...string) (StoredFile, error) {
 	filePath, err := s.fromBasePath(path...)
 	if err != nil {
@@ -70,42 +76,59 @@ func (s *localStorage) Store(r io.Reader, path ...string) (StoredFile, error) {
 		}
 	}
 
-	flags := os.O_RDWR | os.O_CREATE
-	if s.config.Mode == config.REPLACE {
-		flags |= os.O_TRUNC // truncate existing file
-	} else {
-		flags |= os.O_EXCL // file must not exist
+	if s.config.Mode != config.REPLACE {
+		// reserve the file, it must not exist
+		// #nosec G304 fromBasePath does already a path cleanup
+		reserved, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return StoredFile{}, fmt.Errorf("failed to create empty file %s with mode %s %w", filePath, s.config.Mode, err)
+		}
+		if err := reserved.Close(); err != nil {
+			return StoredFile{}, errors.Join(fmt.Errorf("failed to close file %s %w", filePath, err),
+				os.Remove(filePath))
+		}
 	}
 
-	// #nosec G304 fromBasePath does already a path cleanup
-	target, err := os.OpenFile(filePath, flags, 0600)
-	if err != nil {
-		return StoredFile{}, fmt.Errorf("failed to create empty file %s with mode %s %w", filePath, s.config.Mode, err)
-	}
-	defer func(toClose *os.File) {
-		cErr := toClose.Close()
-		if cErr != nil {
-			// report close errors
-			if err == nil {
-				err = cErr
-			} else {
-				err = errors.Join(err, cErr)
-			}
+	if err := writeAndMove(r, filePath); err != nil {
+		if s.config.Mode != config.REPLACE {
+			err = errors.Join(err, os.Remove(filePath))
 		}
-	}(target)
 
-	if _, err = io.Copy(target, r); err != nil {
-		return StoredFile{}, fmt.Errorf("failed to copy file %w", err)
-	}
-
-	if err = target.Sync(); err != nil {
-		return StoredFile{}, fmt.Errorf("failed to sync file %w", err)
+		return StoredFile{}, err
 	}
 
 	return StoredFile{
 		AbsolutePath: filePath,
 		Path:         s.removeBasePath(filePath),
-	}, err
+	}, nil
+}
+
+// writeAndMove Writes the content into a temporary file in the directory of the target and renames it to the target.
+func writeAndMove(r io.Reader, filePath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s %w", filePath, err)
+	}
+	tmpPath := tmp.Name()
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		err = fmt.Errorf("failed to copy file %w", err)
+	} else if sErr := tmp.Sync(); sErr != nil {
+		err = fmt.Errorf("failed to sync file %w", sErr)
+	}
+	if cErr := tmp.Close(); cErr != nil {
+		err = errors.Join(err, cErr)
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return errors.Join(fmt.Errorf("failed to move file to %s %w", filePath, err), os.Remove(tmpPath))
+	}
+
+	return nil
 }
 
 func (s *localStorage) removeBasePath(path string) string {
@@ -138,3 +161,34 @@ func (s *localStorage) Load(path ...string) (io.ReadCloser, error) {
 
 	return file, nil
 }
+
+func (s *localStorage) Delete(path ...string) error {
+	filePath, err := s.fromBasePath(path...)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to delete file %s %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (s *localStorage) Walk(fn func(path string, size int64) error) error {
+	return filepath.WalkDir(s.config.Location, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to get file info %s %w", path, err)
+		}
+
+		return fn(filepath.ToSlash(s.removeBasePath(path)), info.Size())
+	})
+}
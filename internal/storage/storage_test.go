@@ -1,6 +1,7 @@
 package storage_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -118,6 +119,56 @@ func TestStoreModeReplace(t *testing.T) {
 	assertFileContent(t, "differentContent", f.AbsolutePath)
 }
 
+// failingReader Returns the content and fails afterwards like an interrupted download.
+type failingReader struct {
+	content io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.content.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("connection reset")
+	}
+
+	return n, err
+}
+
+func TestStoreFailedWriteLeavesNoFile(t *testing.T) {
+	for _, mode := range []string{config.CREATE, config.REPLACE} {
+		t.Run(mode, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := storage.NewLocalStorage(config.Storage{
+				Location: dir,
+				Mode:     mode,
+			})
+			require.NoError(t, err)
+
+			_, err = store.Store(&failingReader{content: strings.NewReader("partial")}, "dir", "test.txt")
+			require.Error(t, err)
+
+			entries, err := os.ReadDir(filepath.Join(dir, "dir"))
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestStoreModeReplaceFailedWriteKeepsFile(t *testing.T) {
+	store, err := storage.NewLocalStorage(config.Storage{
+		Location: t.TempDir(),
+		Mode:     config.REPLACE,
+	})
+	require.NoError(t, err)
+	fileName := "test.txt"
+	f, err := store.Store(strings.NewReader("content"), fileName)
+	require.NoError(t, err)
+
+	_, err = store.Store(&failingReader{content: strings.NewReader("partial")}, fileName)
+	require.Error(t, err)
+
+	assertFileContent(t, "content", f.AbsolutePath)
+}
+
 func TestLoadNoneExistingFile(t *testing.T) {
 	store, err := storage.NewLocalStorage(config.Storage{
 		Location: t.TempDir(),
@@ -175,6 +226,68 @@ func TestLoad(t *testing.T) {
 	assertContentEquals(t, "content", actual)
 }
 
+func TestDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(config.Storage{
+		Location: dir,
+		Mode:     config.CREATE,
+	})
+	require.NoError(t, err)
+	f, err := store.Store(strings.NewReader("content"), "dir", "test.txt")
+	require.NoError(t, err)
+
+	err = store.Delete("dir", "test.txt")
+
+	require.NoError(t, err)
+	assert.NoFileExists(t, f.AbsolutePath)
+}
+
+func TestDeleteNoneExistingFile(t *testing.T) {
+	store, err := storage.NewLocalStorage(config.Storage{
+		Location: t.TempDir(),
+		Mode:     config.CREATE,
+	})
+	require.NoError(t, err)
+
+	err = store.Delete("test.txt")
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDeleteOutSideBasePath(t *testing.T) {
+	store, err := storage.NewLocalStorage(config.Storage{
+		Location: t.TempDir(),
+		Mode:     config.CREATE,
+	})
+	require.NoError(t, err)
+
+	err = store.Delete("..", "test.txt")
+
+	assert.Error(t, err)
+}
+
+func TestWalk(t *testing.T) {
+	store, err := storage.NewLocalStorage(config.Storage{
+		Location: t.TempDir(),
+		Mode:     config.CREATE,
+	})
+	require.NoError(t, err)
+	_, err = store.Store(strings.NewReader("content"), "test.txt")
+	require.NoError(t, err)
+	_, err = store.Store(strings.NewReader(""), "dir", "sub", "empty.txt")
+	require.NoError(t, err)
+
+	files := map[string]int64{}
+	err = store.Walk(func(path string, size int64) error {
+		files[path] = size
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"test.txt": 7, "dir/sub/empty.txt": 0}, files)
+}
+
 func assertContentEquals(t *testing.T, expected string, r io.Reader) {
 	t.Helper()
 
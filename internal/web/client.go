@@ -19,11 +19,19 @@ type Config struct {
 	Retries     int32         `yaml:"retries"`
 	Retrieables []int         `yaml:"retrieables"`
 	RetryDelay  time.Duration `yaml:"retryDelay"`
+	// RequestsPerSecond limits the requests of all goroutines using the same client, zero means no limit
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"`
+	// Burst amount of requests that can be executed at once before the limit applies
+	Burst int `yaml:"burst"`
 }
 
 type Response struct {
 	Body     io.ReadCloser
 	MimeType MimeType
+	// ETag and LastModified validators of the content, empty if the server doesn't send them.
+	ETag         string
+	LastModified string
+	NotModified  bool // true if the server responded with 304, the body is empty
 }
 
 func NewGetOpts() GetOptions {
@@ -50,28 +58,59 @@ func (o GetOptions) WithExpectedCodes(statusCode ...int) GetOptions {
 	return o
 }
 
+// WithConditional Requests the content only if it differs from the content with the given validators. A 304
+// response is accepted and reported as not modified. Empty validators are not sent.
+func (o GetOptions) WithConditional(etag, lastModified string) GetOptions {
+	if etag != "" {
+		o.Header[HeaderIfNoneMatch] = etag
+	}
+	if lastModified != "" {
+		o.Header[HeaderIfModifiedSince] = lastModified
+	}
+	if !slices.Contains(o.StatusCodes, http.StatusNotModified) {
+		o.StatusCodes = append(slices.Clone(o.StatusCodes), http.StatusNotModified)
+	}
+
+	return o
+}
+
 type Client interface {
 	Get(ctx context.Context, url string, opts GetOptions) (*Response, error)
 }
 
+// NewDownloadHTTPClient Returns a net/http client for large downloads. The timeout only limits the wait for the
+// response header, reading the body is not limited, so the download can only be aborted with the request context.
+func NewDownloadHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = timeout
+
+	return &http.Client{Transport: transport}
+}
+
 func NewClient(cfg Config, client *http.Client) Client {
 	if client == nil {
 		panic("missing net/http client")
 	}
 
 	return &httpClient{
-		cfg:    cfg,
-		client: client,
+		cfg:     cfg,
+		client:  client,
+		limiter: NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst),
 	}
 }
 
 type httpClient struct {
-	cfg    Config
-	client *http.Client
+	cfg     Config
+	client  *http.Client
+	limiter *RateLimiter
 }
 
 func (c *httpClient) Get(ctx context.Context, url string, opts GetOptions) (*Response, error) {
 	return WithRetry(ctx, c.cfg, func() (*http.Response, error) {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
 			return nil, fmt.Errorf("request creation failed for url %s, %w", url, err)
@@ -131,8 +170,11 @@ func WithRetry(ctx context.Context, cfg Config, exec func() (*http.Response, err
 			}
 
 			return &Response{
-				Body:     resp.Body,
-				MimeType: NewMimeType(resp.Header.Get("content-type")),
+				Body:         resp.Body,
+				MimeType:     NewMimeType(resp.Header.Get("content-type")),
+				ETag:         resp.Header.Get(HeaderETag),
+				LastModified: resp.Header.Get(HeaderLastModified),
+				NotModified:  resp.StatusCode == http.StatusNotModified,
 			}, nil
 		}
 	}
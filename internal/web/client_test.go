@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/konstantinfoerster/card-importer-go/internal/test"
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
@@ -110,6 +111,65 @@ func TestNewGetOpts(t *testing.T) {
 	assert.Equal(t, want, actual)
 }
 
+func TestNewGetOptsWithConditional(t *testing.T) {
+	want := web.GetOptions{
+		Header: map[string]string{
+			web.HeaderIfNoneMatch:     `"abc"`,
+			web.HeaderIfModifiedSince: "Wed, 21 Oct 2015 07:28:00 GMT",
+		},
+		StatusCodes: []int{http.StatusOK, http.StatusNotModified},
+	}
+
+	actual := web.NewGetOpts().
+		WithConditional(`"abc"`, "Wed, 21 Oct 2015 07:28:00 GMT").
+		WithConditional("", "")
+
+	assert.Equal(t, want, actual)
+}
+
+func TestGet_Conditional(t *testing.T) {
+	etag := `"v1"`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(web.HeaderETag, etag)
+		w.Header().Set(web.HeaderLastModified, "Wed, 21 Oct 2015 07:28:00 GMT")
+		if r.Header.Get(web.HeaderIfNoneMatch) == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer ts.Close()
+	client := web.NewClient(web.Config{}, http.DefaultClient)
+
+	t.Run("without validators", func(t *testing.T) {
+		resp, err := client.Get(t.Context(), ts.URL, web.NewGetOpts().WithConditional("", ""))
+		require.NoError(t, err)
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+		assert.False(t, resp.NotModified)
+		assert.Equal(t, etag, resp.ETag)
+		assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", resp.LastModified)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		resp, err := client.Get(t.Context(), ts.URL, web.NewGetOpts().WithConditional(etag, ""))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.True(t, resp.NotModified)
+	})
+
+	t.Run("not modified without conditional is an error", func(t *testing.T) {
+		_, err := client.Get(t.Context(), ts.URL, web.NewGetOpts().WithHeader(web.HeaderIfNoneMatch, etag))
+
+		assert.True(t, web.IsStatusCode(err, http.StatusNotModified))
+	})
+}
+
 func fileContent(t *testing.T, path string) []byte {
 	t.Helper()
 
@@ -118,3 +178,52 @@ func fileContent(t *testing.T, path string) []byte {
 
 	return content
 }
+
+func TestGet_RateLimit(t *testing.T) {
+	cfg := web.Config{RequestsPerSecond: 20, Burst: 1}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	client := web.NewClient(cfg, http.DefaultClient)
+
+	start := time.Now()
+	for range 3 {
+		resp, err := client.Get(t.Context(), ts.URL, web.NewGetOpts())
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// first request uses the burst, the other two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestNewDownloadHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow-header" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("second"))
+	}))
+	defer ts.Close()
+	client := web.NewClient(web.Config{}, web.NewDownloadHTTPClient(100*time.Millisecond))
+
+	t.Run("body is not limited by the timeout", func(t *testing.T) {
+		resp, err := client.Get(t.Context(), ts.URL+"/slow-body", web.NewGetOpts())
+		require.NoError(t, err)
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		require.NoError(t, err)
+		assert.Equal(t, "firstsecond", string(content))
+	})
+
+	t.Run("header is limited by the timeout", func(t *testing.T) {
+		_, err := client.Get(t.Context(), ts.URL+"/slow-header", web.NewGetOpts())
+
+		require.Error(t, err)
+	})
+}
@@ -9,10 +9,17 @@ const (
 	MimeTypeJSON     = "application/json"
 	MimeTypeJpeg     = "image/jpeg"
 	MimeTypePng      = "image/png"
+	MimeTypeGif      = "image/gif"
+	MimeTypeSvg      = "image/svg+xml"
 	MimeTypeZip      = "application/zip"
 	HeaderAccept     = "Accept"
 	HeaderUserAgent  = "User-Agent"
 	DefaultUserAgent = "CardImporter/0.1"
+
+	HeaderETag            = "ETag"
+	HeaderLastModified    = "Last-Modified"
+	HeaderIfNoneMatch     = "If-None-Match"
+	HeaderIfModifiedSince = "If-Modified-Since"
 )
 
 // NewMimeType creates a MimeType from the given content-type.
@@ -42,6 +49,10 @@ func (m MimeType) BuildFilename(name string) (string, error) {
 		return name + ".jpg", nil
 	case MimeTypePng:
 		return name + ".png", nil
+	case MimeTypeGif:
+		return name + ".gif", nil
+	case MimeTypeSvg:
+		return name + ".svg", nil
 	default:
 		return "", fmt.Errorf("unsupported mime type %s", m.value)
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/konstantinfoerster/card-importer-go/internal/web"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMimeTypeRaw(t *testing.T) {
@@ -47,3 +48,32 @@ func TestMimeTypeRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestMimeTypeBuildFilename(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		want        string
+	}{
+		{name: "json", contentType: "application/json", want: "file.json"},
+		{name: "zip", contentType: "application/zip", want: "file.zip"},
+		{name: "jpeg", contentType: "image/jpeg", want: "file.jpg"},
+		{name: "png", contentType: "image/png", want: "file.png"},
+		{name: "gif", contentType: "image/gif", want: "file.gif"},
+		{name: "svg", contentType: "image/svg+xml; charset=utf-8", want: "file.svg"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := web.NewMimeType(tc.contentType).BuildFilename("file")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("unsupported mime type", func(t *testing.T) {
+		_, err := web.NewMimeType("image/webp").BuildFilename("file")
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter A token bucket that allows up to burst requests at once and refills with the configured amount of
+// requests per second. The limiter can be shared by multiple goroutines.
+type RateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+// NewRateLimiter Creates a limiter with the given requests per second. A burst lower than 1 is treated as 1.
+// Returns nil if requestsPerSecond is not positive, a nil limiter does not limit any request.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	b := float64(max(burst, 1))
+
+	return &RateLimiter{
+		rate:     requestsPerSecond,
+		burst:    b,
+		tokens:   b,
+		lastFill: time.Now(),
+	}
+}
+
+// Wait Blocks until a token is available or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+
+			return fmt.Errorf("stop waiting for rate limit due to cancelled context %w", ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// reserve Takes a token and returns zero or returns the duration until the next token is available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(l.lastFill).Seconds()
+	l.lastFill = now
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+
+	if l.tokens >= 1 {
+		l.tokens--
+
+		return 0
+	}
+
+	missing := 1 - l.tokens
+
+	return time.Duration(missing / l.rate * float64(time.Second))
+}
@@ -0,0 +1,56 @@
+package web_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konstantinfoerster/card-importer-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_NoLimit(t *testing.T) {
+	limiter := web.NewRateLimiter(0, 0)
+
+	assert.Nil(t, limiter)
+	assert.NoError(t, limiter.Wait(t.Context()))
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := web.NewRateLimiter(1, 3)
+
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, limiter.Wait(t.Context()))
+	}
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRateLimiter_SharedByGoroutines(t *testing.T) {
+	limiter := web.NewRateLimiter(50, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			assert.NoError(t, limiter.Wait(t.Context()))
+		})
+	}
+	wg.Wait()
+
+	// first token is available immediately, the other four wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimiter_CancelledContext(t *testing.T) {
+	limiter := web.NewRateLimiter(0.1, 1)
+	require.NoError(t, limiter.Wait(t.Context()))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}